@@ -0,0 +1,71 @@
+package blackjack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Action is a move a player can make on their hand.
+type Action int
+
+const (
+	// Hit draws another card.
+	Hit Action = iota + 1
+	// Stay ends the turn.
+	Stay
+	// Double doubles the wager, draws one card and ends the turn.
+	Double
+	// Split separates a pair into two hands.
+	Split
+	// Surrender gives up half of the wager and ends the turn.
+	Surrender
+	// Switch swaps the second cards of a seat's two hands before either is played.
+	Switch
+)
+
+//go:generate stringer -type=Action
+
+// ParseAction returns the Action with the specified name, ignoring case.
+func ParseAction(name string) (Action, bool) {
+	for a := Action(1); int(a) < len(_Action_index); a++ {
+		if strings.EqualFold(a.String(), name) {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// MarshalText encodes the action as its name.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes an action from its name.
+func (a *Action) UnmarshalText(text []byte) error {
+	parsed, ok := ParseAction(string(text))
+	if !ok {
+		return fmt.Errorf("blackjack: unknown action %q", text)
+	}
+	*a = parsed
+	return nil
+}
+
+// Act performs the specified action for the current player. Act returns false if the
+// action is not allowed.
+func (d *Dealer) Act(a Action) bool {
+	switch a {
+	case Hit:
+		return d.Hit()
+	case Stay:
+		return d.Stay()
+	case Double:
+		return d.Double()
+	case Split:
+		return d.Split()
+	case Surrender:
+		return d.Surrender()
+	case Switch:
+		return d.Switch()
+	}
+	return false
+}
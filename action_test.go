@@ -0,0 +1,50 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestParseAction(t *testing.T) {
+	for _, a := range []Action{Hit, Stay, Double, Split, Surrender} {
+		parsed, ok := ParseAction(a.String())
+		if !ok || parsed != a {
+			t.Fatalf("expected %s to be parsed but got %v", a, parsed)
+		}
+	}
+	if a, ok := ParseAction("split"); !ok || a != Split {
+		t.Fatalf("expected action names to be parsed ignoring case")
+	}
+	if _, ok := ParseAction("twist"); ok {
+		t.Fatalf("expected twist to not be an action")
+	}
+}
+
+func TestDealerAct(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.Three}, {Rank: cards.Four}, {Rank: cards.Five}, {Rank: cards.Six},
+	})
+	game.Dealer.Deal(2, game.Players)
+
+	if game.Dealer.Act(Hit) {
+		t.Fatalf("expected the dealer to refuse an action before the game has started")
+	}
+
+	game.Start()
+
+	if !game.Dealer.Act(Hit) || len(game.Players.Head.Hand) != 3 {
+		t.Fatalf("expected the dealer to hit the current player")
+	}
+	if game.Dealer.Act(Split) {
+		t.Fatalf("expected the dealer to refuse to split a hand without a pair")
+	}
+	if !game.Dealer.Act(Stay) || !game.PlayersPlayed() {
+		t.Fatalf("expected the dealer to end the players turn on stay")
+	}
+	if game.Dealer.Act(Action(0)) {
+		t.Fatalf("expected the dealer to refuse an unknown action")
+	}
+}
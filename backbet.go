@@ -0,0 +1,58 @@
+package blackjack
+
+// BackBet is a wager placed by a player on a hand played by another player. A back bet
+// wins and loses along with the hand it is placed on.
+type BackBet struct {
+	// Player is the player who placed the back bet.
+	Player *Player
+	// Wager is how much the back bet is worth to the player's winnings.
+	Wager int
+	// FollowDouble is whether the wager is doubled when the hand is doubled.
+	FollowDouble bool
+	// FollowSplit is whether the wager is also placed on the new hand when the hand is
+	// split. A back bet that declines to follow a split stays on the first hand.
+	FollowSplit bool
+}
+
+// BackBet places the back bet on the specified hand. BackBet returns false if the back
+// bet is placed by the hand's own player, the hand has been hit or the wager is outside
+// of the game's betting limits.
+func (d *Dealer) BackBet(listVal *ListVal, bet *BackBet) bool {
+	if listVal == nil || bet == nil || bet.Player == nil || bet.Player == listVal.Player {
+		return false
+	}
+	if len(listVal.Hand) > 2 || bet.Wager <= 0 || !d.Game.Rules.AllowsBet(bet.Wager) {
+		return false
+	}
+	listVal.BackBets = append(listVal.BackBets, bet)
+	d.Game.emit(BackBetPlaced{Hand: listVal, Bet: bet})
+	return true
+}
+
+// followDouble doubles the back bets on the hand that follow doubles.
+func (val *ListVal) followDouble() {
+	for _, bet := range val.BackBets {
+		if bet.FollowDouble {
+			bet.Wager *= 2
+		}
+	}
+}
+
+// followSplit places a copy of the back bets on the hand that follow splits on the new
+// hand split from it.
+func (val *ListVal) followSplit(next *ListVal) {
+	for _, bet := range val.BackBets {
+		if bet.FollowSplit {
+			copied := *bet
+			next.BackBets = append(next.BackBets, &copied)
+		}
+	}
+}
+
+// followSurrender takes half of every back bet on the hand.
+func (val *ListVal) followSurrender() {
+	for _, bet := range val.BackBets {
+		bet.Player.Winnings -= bet.Wager / 2
+		bet.Wager = 0
+	}
+}
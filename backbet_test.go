@@ -0,0 +1,109 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestDealerBackBet(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	val := game.Players.Head
+	val.Hand = Hand{{Rank: cards.Ten, Suit: cards.Spades}, {Rank: cards.Nine, Suit: cards.Spades}}
+
+	if game.Dealer.BackBet(val, &BackBet{Player: a, Wager: 5}) {
+		t.Fatalf("expected a player to be refused a back bet on their own hand")
+	}
+	if game.Dealer.BackBet(val, &BackBet{Player: b}) {
+		t.Fatalf("expected a back bet without a wager to be refused")
+	}
+	if !game.Dealer.BackBet(val, &BackBet{Player: b, Wager: 5}) {
+		t.Fatalf("expected b to back a's hand")
+	}
+	val.Hand.Draw(cards.Card{Rank: cards.Two, Suit: cards.Spades})
+	if game.Dealer.BackBet(val, &BackBet{Player: b, Wager: 5}) {
+		t.Fatalf("expected a back bet on a hand that has been hit to be refused")
+	}
+}
+
+func TestDealerBackBetCollect(t *testing.T) {
+	game := New()
+	a, b, c := NewPlayer("a"), NewPlayer("b"), NewPlayer("c")
+	game.AddPlayer(a)
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.BackBet(val, &BackBet{Player: b, Wager: 4, FollowDouble: true})
+	game.Dealer.BackBet(val, &BackBet{Player: c, Wager: 6})
+
+	game.Dealer.Stack(cards.Deck{{Rank: cards.Ten, Suit: cards.Clubs}})
+	val.Hand = Hand{{Rank: cards.Six, Suit: cards.Spades}, {Rank: cards.Five, Suit: cards.Spades}}
+	game.Dealer.hand = Hand{{Rank: cards.Ten, Suit: cards.Hearts}, {Rank: cards.Eight, Suit: cards.Hearts}}
+	game.Start()
+	game.Dealer.Double()
+
+	var settled []BackBetSettled
+	game.Listen(func(e Event) {
+		if e, ok := e.(BackBetSettled); ok {
+			settled = append(settled, e)
+		}
+	})
+	game.Dealer.Collect()
+
+	if a.Winnings != 20 || b.Winnings != 8 || c.Winnings != 6 {
+		t.Fatalf("expected winnings of 20, 8 and 6 but were %d, %d and %d", a.Winnings, b.Winnings, c.Winnings)
+	}
+	if len(settled) != 2 || settled[0].Bet.Player != b || settled[0].State.Type != Win {
+		t.Fatalf("expected both back bets to be settled as wins")
+	}
+}
+
+func TestDealerBackBetSplit(t *testing.T) {
+	game := New()
+	a, b, c := NewPlayer("a"), NewPlayer("b"), NewPlayer("c")
+	game.AddPlayer(a)
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.BackBet(val, &BackBet{Player: b, Wager: 4, FollowSplit: true})
+	game.Dealer.BackBet(val, &BackBet{Player: c, Wager: 6})
+
+	eight := cards.Card{Rank: cards.Eight, Suit: cards.Spades}
+	game.Dealer.Stack(cards.Deck{{Rank: cards.Ten, Suit: cards.Clubs}, {Rank: cards.Two, Suit: cards.Clubs}})
+	val.Hand = Hand{eight, eight}
+	game.Dealer.hand = Hand{{Rank: cards.Ten, Suit: cards.Hearts}, {Rank: cards.Seven, Suit: cards.Hearts}}
+	game.Start()
+	game.Dealer.Split()
+
+	next := game.Players.Tail.Head
+	if len(val.BackBets) != 2 || len(next.BackBets) != 1 || next.BackBets[0].Player != b {
+		t.Fatalf("expected only b's back bet to follow the split")
+	}
+	next.BackBets[0].Wager = 1
+	if val.BackBets[0].Wager != 4 {
+		t.Fatalf("expected a back bet that follows a split to be a separate wager")
+	}
+
+	// a wins 18 to 17 on the first hand and loses 10 to 17 on the second
+	game.Dealer.Stay()
+	game.Dealer.Stay()
+	game.Dealer.Collect()
+	if a.Winnings != 0 || b.Winnings != 3 || c.Winnings != 6 {
+		t.Fatalf("expected winnings of 0, 3 and 6 but were %d, %d and %d", a.Winnings, b.Winnings, c.Winnings)
+	}
+}
+
+func TestDealerBackBetSurrender(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.BackBet(val, &BackBet{Player: b, Wager: 4})
+	game.Start()
+	game.Dealer.Surrender()
+
+	if a.Winnings != -5 || b.Winnings != -2 || val.BackBets[0].Wager != 0 {
+		t.Fatalf("expected the back bet to lose half along with the surrendered hand")
+	}
+}
@@ -0,0 +1,38 @@
+package blackjack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	var calls []string
+	clock.AfterFunc(2*time.Second, func() { calls = append(calls, "second") })
+	clock.AfterFunc(time.Second, func() {
+		calls = append(calls, "first")
+		clock.AfterFunc(time.Second, func() { calls = append(calls, "rescheduled") })
+	})
+	stopped := clock.AfterFunc(time.Second, func() { calls = append(calls, "stopped") })
+	if !stopped.Stop() {
+		t.Fatalf("expected a pending timer to be stopped")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if len(calls) != 0 {
+		t.Fatalf("expected no timers to fire before they are due but %v fired", calls)
+	}
+
+	clock.Advance(2 * time.Second)
+	if len(calls) != 3 || calls[0] != "first" {
+		t.Fatalf("expected the due timers to fire in order but got %v", calls)
+	}
+	if !clock.Now().Equal(start.Add(2500 * time.Millisecond)) {
+		t.Fatalf("expected the clock to have advanced 2.5 seconds but is at %v", clock.Now())
+	}
+	if stopped.Stop() {
+		t.Fatalf("expected a stopped timer to not be stopped again")
+	}
+}
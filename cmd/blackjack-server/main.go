@@ -0,0 +1,18 @@
+// Command blackjack-server hosts blackjack tables behind a JSON API.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/ethanefung/blackjack/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server.New()))
+}
@@ -10,6 +10,10 @@ type Dealer struct {
 	hand  Hand
 	index int
 	Game  *Game
+
+	// hands are the dealer's hands drawn to the upcard for the bets after the first of
+	// Multi-Action hands.
+	hands []Hand
 }
 
 // NewDealer returns a Dealer when given an instantiated game.
@@ -17,6 +21,14 @@ func NewDealer(g *Game) *Dealer {
 	return &Dealer{Game: g}
 }
 
+// UseRules will cause the dealer to use a shoe with the number of decks specified by the
+// game's rules, made of the decks of the game's variant.
+func (d *Dealer) UseRules() {
+	d.deck = d.Game.Rules.variant().deck()
+	d.deck.Multiply(d.Game.Rules.ShoeSize())
+	d.index = 0
+}
+
 // UseDecks will cause dealer to use a deck with 52 * n cards for gameplay.
 func (d *Dealer) UseDecks(n int) {
 	d.deck = cards.New()
@@ -24,185 +36,365 @@ func (d *Dealer) UseDecks(n int) {
 	d.index = 0
 }
 
+// Stack will cause the dealer to deal the specified cards in order. This is useful
+// for reproducing a round from a known shoe.
+func (d *Dealer) Stack(deck cards.Deck) {
+	d.deck = deck
+	d.index = 0
+}
+
+// Shoe returns a copy of the cards that have yet to be dealt, in the order they will be
+// dealt.
+func (d *Dealer) Shoe() cards.Deck {
+	if d.index >= len(d.deck) {
+		return cards.Deck{}
+	}
+	return append(cards.Deck{}, d.deck[d.index:]...)
+}
+
+// Remaining returns the number of cards that have yet to be dealt.
+func (d *Dealer) Remaining() int {
+	if d.index >= len(d.deck) {
+		return 0
+	}
+	return len(d.deck) - d.index
+}
+
 // Shuffle will shuffle the deck the dealer is using.
 func (d *Dealer) Shuffle(seed int64) {
 	d.deck.Shuffle(seed)
+	d.Game.emit(ShoeReshuffled{Seed: seed})
 }
 
 // Deal will append the specified count of cards to all players within the Player's
 // list. Deal will also append the specified count of card to the dealer's own hand.
 func (d *Dealer) Deal(count int, p *PlayersList) {
+	if len(d.hand) == 0 {
+		d.Game.Rules.variant().prepare(d.Game)
+		d.Game.emit(RoundStarted{Cards: count})
+	}
 	for i := 0; i < count; i++ {
 		for curr := p; curr != nil; curr = curr.Tail {
-			if d.index == len(d.deck) {
+			if !d.draw(curr.Head) {
 				return
 			}
-			curr.Head.Hand.Draw(d.deck[d.index])
-			d.index++
 		}
-		d.hand.Draw(d.deck[d.index])
-		d.index++
+		if !d.draw(nil) {
+			return
+		}
 	}
+	d.keepSideBetCards()
 }
 
-// Hit will add a card for to the current players hand.
-func (d *Dealer) Hit() bool {
-	if d.Game.Current == nil {
+// draw takes the next card from the deck and adds it to the hand of the specified
+// list value, or to the dealer's own hand if the list value is nil. draw returns false if
+// the shoe has run out of cards.
+func (d *Dealer) draw(val *ListVal) bool {
+	if d.Remaining() == 0 {
 		return false
 	}
-	listVal := d.Game.Current.Head
-	listVal.Hand.Draw(d.deck[d.index])
+	card := d.deck[d.index]
 	d.index++
+	if val == nil {
+		// the dealer's first card is the hole card
+		faceUp := !d.Game.Rules.variant().hidden(len(d.hand))
+		d.hand.Draw(card)
+		d.Game.emit(CardDealt{Card: card, FaceUp: faceUp})
+		return true
+	}
+	val.Hand.Draw(card)
+	d.Game.emit(CardDealt{Hand: val, Card: card, FaceUp: true})
+	if val.Hand.Value() > 21 {
+		d.Game.emit(HandBusted{Hand: val})
+	}
+	return true
+}
+
+// allows returns true if the game's variant allows the action on the specified hand.
+func (d *Dealer) allows(val *ListVal, a Action) bool {
+	return val != nil && d.Game.Rules.variant().allows(d.Game, val, a)
+}
+
+// Hit will add a card for to the current players hand. Hit returns false if the shoe
+// has run out of cards.
+func (d *Dealer) Hit() bool {
+	listVal := d.Game.current()
+	if !d.allows(listVal, Hit) || d.Remaining() < 1 {
+		return false
+	}
+	d.Game.emit(ActionTaken{Hand: listVal, Action: Hit})
+	d.draw(listVal)
 	return true
 }
 
 // Stay changes the game's current player to either the next player in the Players
-// list or changes current to null.
-func (d *Dealer) Stay() {
+// list or changes current to null. Stay returns false if the game's variant requires
+// the hand to take another card.
+func (d *Dealer) Stay() bool {
+	listVal := d.Game.current()
+	if !d.allows(listVal, Stay) {
+		return false
+	}
+	d.Game.emit(ActionTaken{Hand: listVal, Action: Stay})
 	d.Game.EndPlayerTurn()
+	return true
 }
 
 // Surrender will first subtract half of the wager amount from the current players
 // winnings and set the bet amount to zero. Surrender will also end the players turn.
+// Surrendering a doubled hand forfeits the wager it was dealt with. Surrender returns
+// false for a hand with a free wager, since the house put it up.
 func (d *Dealer) Surrender() bool {
-	if d.Game.Current == nil {
+	player := d.Game.current()
+	if !d.allows(player, Surrender) || player.Free > 0 {
 		return false
 	}
-	player := d.Game.Current.Head
 	half := player.Wager / 2
-	d.Game.Current.Head.Player.Winnings -= half
-	d.Game.Current.Head.Wager = 0
+	player.Player.Winnings -= half
+	player.Wager = 0
+	player.followSurrender()
+	d.Game.emit(ActionTaken{Hand: player, Action: Surrender})
 	d.Game.EndPlayerTurn()
 	return true
 }
 
 // Double will multiply the current players wager by 2 and hit if the player has only
-// two cards.
+// two cards, or any number of cards if the game's variant allows it. The player pays
+// the hand's whole stake, including a free wager, to double it. The player's turn ends
+// unless the variant lets the doubled hand be rescued by surrendering it. Double returns
+// false if the shoe has run out of cards.
 // ! To note that this shouldn't be allowed on split hands
 func (d *Dealer) Double() bool {
-	if d.Game.Current == nil {
+	player := d.Game.current()
+	if !d.allows(player, Double) || d.Remaining() < 1 {
 		return false
 	}
-	player := d.Game.Current.Head
-	if len(player.Hand) > 2 {
-		return false
+	if d.Game.Rules.variant().free(player, Double) {
+		player.Free += player.Wager + player.Free
+	} else {
+		player.Wager += player.Wager + player.Free
+		for i := range player.Extra {
+			player.Extra[i] *= 2
+		}
+	}
+	player.Doubled = true
+	player.followDouble()
+	d.Game.emit(ActionTaken{Hand: player, Action: Double})
+	d.draw(player)
+	if !d.Game.Rules.variant().rescues() || player.Hand.Value() >= 21 || d.done(player) {
+		d.Game.EndPlayerTurn()
 	}
-	d.Game.Current.Head.Wager *= 2
-	d.Hit()
-	d.Game.EndPlayerTurn()
 	return true
 }
 
+// Split will separate the current players pair into two hands, each with the original
+// wager, and deal a second card to both hands. The wager of the new hand is put up by the
+// house if the game's variant splits the pair for free. Split returns false if the shoe
+// does not hold a card for both hands.
 func (d *Dealer) Split() bool {
-	val := d.Game.Current.Head
-	if len(val.Hand) != 2 || !val.Hand.HasPair() {
+	val := d.Game.current()
+	if !d.allows(val, Split) || len(val.Hand) != 2 || !val.Hand.HasPair() || d.Remaining() < 2 {
 		return false
 	}
 	next := &ListVal{
 		Player: val.Player,
 		Hand:   Hand{val.Hand[1]},
 		Wager:  val.Wager,
+		Extra:  append([]int(nil), val.Extra...),
 		Split:  true,
+		Seat:   val.Seat,
 	}
-
-	tail := d.Game.Current.Tail
-	d.Game.Current.Tail = &PlayersList{
-		Head: next,
-		Tail: tail,
+	if d.Game.Rules.variant().free(val, Split) {
+		next.Wager, next.Free = 0, val.Wager+val.Free
 	}
-	d.Game.Current.Head.Hand = Hand{val.Hand[0]}
-	d.Game.Current.Head.Hand.Draw(d.deck[d.index])
-	d.index++
-	next.Hand.Draw(d.deck[d.index])
-	d.index++
+	val.followSplit(next)
+
+	d.Game.Hands().InsertAfter(val, next)
+	val.Hand = Hand{val.Hand[0]}
+	d.Game.emit(ActionTaken{Hand: val, Action: Split})
+	d.draw(val)
+	d.draw(next)
 	return true
 }
 
-// Bet will change the Wager of the player to the specified amount.
+// Bet will change the Wager of the player to the specified amount, removing any further
+// wagers of a Multi-Action hand. Bet returns false if the wager is outside of the game's
+// betting limits.
 func (d *Dealer) Bet(listVal *ListVal, wager int) bool {
-	if listVal == nil {
+	if listVal == nil || !d.Game.Rules.AllowsBet(wager) {
 		return false
 	}
 	listVal.Wager = wager
+	listVal.Extra = nil
+	d.Game.emit(BetPlaced{Hand: listVal, Wager: wager})
 	return true
 }
 
-// Collect resolves all game Players Winnings based on the state of the game.
+// Collect resolves all game Players Winnings based on the state of the game. The free
+// wager of a hand is only paid when the hand wins, and every further wager of a
+// Multi-Action hand is settled against the dealer's hand drawn for it. The side bets of
+// every hand are settled first.
 func (d *Dealer) Collect() {
+	d.settleSideBets()
 
-	states := d.Game.State().Players
-	var last *Player
-	var i int
-	for curr := d.Game.Players; curr != nil; curr = curr.Tail {
-		listVal := curr.Head
-		if last == listVal.Player {
-			i++
-		} else {
-			i = 0
-		}
-		state := states[listVal.Player][i]
-		switch state.Type {
-		case Push:
-			continue
-		case Win:
-			listVal.Player.Winnings += curr.Head.Wager
-		case Lose:
-			listVal.Player.Winnings -= curr.Head.Wager
-		case Bust:
-			listVal.Player.Winnings -= curr.Head.Wager
-		default:
+	rules := d.Game.Rules
+	states := d.Game.State().Hands
+	for _, listVal := range d.Game.Hands().All() {
+		state := states[listVal.ID].State
+		payout, ok := rules.settle(listVal, state, listVal.Wager)
+		if !ok {
 			continue
 		}
+		if free, _ := rules.settle(listVal, state, listVal.Free); free > 0 {
+			payout += free
+		}
+		listVal.Player.Winnings += payout
+		d.Game.emit(HandSettled{Hand: listVal, State: state, Payout: payout})
+
+		for _, bet := range listVal.BackBets {
+			payout, _ := rules.settle(listVal, state, bet.Wager)
+			bet.Player.Winnings += payout
+			d.Game.emit(BackBetSettled{Hand: listVal, Bet: bet, State: state, Payout: payout})
+		}
+
+		for i, wager := range listVal.Extra {
+			state := states[listVal.ID].Bets[i+1]
+			payout, ok := rules.settle(listVal, state, wager)
+			if !ok {
+				continue
+			}
+			listVal.Player.Winnings += payout
+			d.Game.emit(HandSettled{Hand: listVal, State: state, Payout: payout, Bet: i + 1})
+		}
+	}
+}
 
+// settle returns the change to a player's winnings for a wager on a hand in the
+// specified state, or false if the hand is yet to be determined.
+func (r Rules) settle(val *ListVal, state WinState, wager int) (int, bool) {
+	switch state.Type {
+	case Undetermined:
+		return 0, false
+	case Charlie:
+		// a Charlie is paid as any other winning hand
+		return r.variant().payout(val, Win, wager), true
 	}
+	return r.variant().payout(val, state.Type, wager), true
 }
 
 // Play appends cards to the dealers hand as long as the value of the dealers hand is
-// either below 17 or if the dealer has hand value of 17 and an ace.
+// either below 17 or if the dealer has hand value of 17 and an ace, unless the game's
+// rules have the dealer stand on soft 17. For every bet after the first of Multi-Action
+// hands, the dealer then keeps the upcard and draws a fresh hand to it the same way. The
+// dealer stands if the shoe runs out of cards.
 func (d *Dealer) Play() {
-	for d.hand.Value() < 17 || (d.hand.Value() == 17 && d.hand.HasAce()) {
-		d.hand.Draw(d.deck[d.index])
-		d.index++
+	d.Game.emit(DealerRevealed{Hand: append(Hand{}, d.hand...)})
+	for d.Game.Rules.DealerHits(d.hand) && d.draw(nil) {
+	}
+	if len(d.hand) < 2 {
+		return
+	}
+	for bet := len(d.hands) + 1; bet < d.bets(); bet++ {
+		d.hands = append(d.hands, Hand{})
+		d.drawBet(bet)
+		d.hands[bet-1].Draw(d.hand[1])
+		for d.Game.Rules.DealerHits(d.hands[bet-1]) && d.drawBet(bet) {
+		}
+	}
+}
+
+// bets returns the most bets played on any hand.
+func (d *Dealer) bets() int {
+	n := 1
+	for _, val := range d.Game.Hands().All() {
+		if 1+len(val.Extra) > n {
+			n = 1 + len(val.Extra)
+		}
+	}
+	return n
+}
+
+// drawBet takes the next card from the deck and adds it face up to the dealer's hand
+// drawn for the bet at the specified position of Multi-Action hands. drawBet returns false
+// if the shoe has run out of cards.
+func (d *Dealer) drawBet(bet int) bool {
+	if d.Remaining() == 0 {
+		return false
+	}
+	card := d.deck[d.index]
+	d.index++
+	d.hands[bet-1].Draw(card)
+	d.Game.emit(CardDealt{Card: card, FaceUp: true, Bet: bet})
+	return true
+}
+
+// Hands returns every hand of the dealer: the hand of ShowHand followed by the hands
+// drawn for the bets after the first of Multi-Action hands once they have been played.
+func (d *Dealer) Hands() []Hand {
+	hands := []Hand{append(Hand{}, d.ShowHand()...)}
+	for _, h := range d.hands {
+		hands = append(hands, append(Hand{}, h...))
 	}
+	return hands
 }
 
 // Evaluate will change the game's current player if the current players hand value is
-// over 21.
+// over 21, the hand is a Charlie, or the hand may take no more cards in the game's
+// variant.
 func (d *Dealer) Evaluate() {
-	if d.Game.Current == nil {
-		return
-	}
-	if d.Game.Current.Head.Hand.Value() > 21 {
-		d.Game.Current = d.Game.Current.Tail
+	if val := d.Game.current(); val != nil && d.done(val) {
+		d.Game.EndPlayerTurn()
 	}
 }
 
+// done returns true if the hand may take no more cards.
+func (d *Dealer) done(val *ListVal) bool {
+	return d.Game.Rules.charlie(val.Hand) || d.Game.Rules.variant().done(val)
+}
+
 // Clear removes all cards from players and dealer's hands.
 func (d *Dealer) Clear() {
-	curr := d.Game.Players
-	for curr != nil {
-		curr.Head.Hand = Hand{}
-		curr = curr.Tail
+	for _, val := range d.Game.Hands().All() {
+		val.Hand = Hand{}
+		val.Doubled = false
+		val.Switched = false
+		val.Free = 0
+		// side bets of the last round are dropped, while those placed for the next round
+		// are kept
+		var kept []*SideWager
+		for _, w := range val.SideBets {
+			if !w.Settled {
+				kept = append(kept, w)
+			}
+		}
+		val.SideBets = kept
 	}
 	d.hand = Hand{}
+	d.hands = nil
 }
 
 // ShowHand will return dealers full hand if all players have taken their turns for
-// the round
+// the round, and otherwise the cards the game's variant deals face up.
 func (d *Dealer) ShowHand() Hand {
-	if d.Game.PlayersPlayed() {
+	if d.Game.PlayersPlayed() || len(d.hand) == 0 {
 		return d.hand
 	}
-	return d.hand[1:]
+	shown := Hand{}
+	for i, card := range d.hand {
+		if !d.Game.Rules.variant().hidden(i) {
+			shown = append(shown, card)
+		}
+	}
+	return shown
 }
 
+// ResetTable removes the list values that were split from another during the round,
+// along with the second hands of a Blackjack Switch seat.
 func (d *Dealer) ResetTable() {
-	curr := d.Game.Players
-	for curr.Tail != nil {
-		if curr.Tail.Head.Split {
-			curr.Tail = curr.Tail.Tail
-		} else {
-			curr = curr.Tail
+	hands := d.Game.Hands()
+	for _, val := range hands.All() {
+		if val.Split || val.Second {
+			hands.Remove(val)
 		}
 	}
 }
@@ -201,6 +201,42 @@ func TestDealerEvaluate(t *testing.T) {
 	}
 }
 
+func TestDealerEvaluateCharlie(t *testing.T) {
+	game := New()
+	game.Rules.Charlie = 5
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.King}, {Rank: cards.Three}, {Rank: cards.Ace},
+		{Rank: cards.Two}, {Rank: cards.Three}, {Rank: cards.Four},
+	})
+	game.Dealer.Bet(game.Players.Head, 10)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	for i := 0; i < 2; i++ {
+		game.Dealer.Hit()
+		game.Dealer.Evaluate()
+	}
+	if game.PlayersPlayed() {
+		t.Fatalf("expected a hand of four cards to still be played")
+	}
+	game.Dealer.Hit()
+	game.Dealer.Evaluate()
+	if !game.PlayersPlayed() {
+		t.Fatalf("expected a five card Charlie to end the turn")
+	}
+
+	game.Dealer.Play()
+	if state := game.State().Hands[game.Players.Head.ID].State; state.Type != Charlie || state.Value != 14 {
+		t.Fatalf("expected the hand to be reported as a Charlie against the dealer's natural but was %+v", state)
+	}
+	game.Dealer.Collect()
+	if a.Winnings != 10 {
+		t.Fatalf("expected the Charlie to be paid as a win but winnings were %d", a.Winnings)
+	}
+}
+
 func TestDealerShowHand(t *testing.T) {
 	game := New()
 
@@ -506,3 +542,73 @@ func TestDealerResetTable(t *testing.T) {
 		t.Fatalf("expected that after the dealer.ResetTable is called that the split list val would be removed but it was not")
 	}
 }
+
+func TestDealerStack(t *testing.T) {
+	game := New()
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+
+	game.Dealer.UseDecks(1)
+	game.Dealer.Shuffle(0)
+
+	stacked := cards.Deck{
+		{Rank: cards.Ace, Suit: cards.Spades},
+		{Rank: cards.Nine, Suit: cards.Clubs},
+		{Rank: cards.King, Suit: cards.Hearts},
+		{Rank: cards.Seven, Suit: cards.Diamonds},
+	}
+	game.Dealer.Stack(stacked)
+	game.Dealer.Deal(2, game.Players)
+
+	hand := game.Players.Head.Hand
+	if hand[0] != stacked[0] || hand[1] != stacked[2] {
+		t.Fatalf("expected the player to be dealt the first and third cards of the stacked deck but got %v", hand)
+	}
+
+	if game.Dealer.hand[0] != stacked[1] || game.Dealer.hand[1] != stacked[3] {
+		t.Fatalf("expected the dealer to be dealt the second and fourth cards of the stacked deck but got %v", game.Dealer.hand)
+	}
+}
+
+func TestDealerCollectSpots(t *testing.T) {
+	game := New()
+	game.Rules = Rules{Seats: 3, Spots: 2}
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.Sit(a, 1)
+	game.Sit(b, 2)
+	game.Sit(a, 3)
+
+	ten := cards.Card{Rank: cards.Ten, Suit: cards.Spades}
+	hands := []Hand{
+		{ten, {Rank: cards.Ten, Suit: cards.Hearts}},
+		{ten, {Rank: cards.Eight, Suit: cards.Hearts}},
+		{ten, {Rank: cards.Six, Suit: cards.Hearts}},
+	}
+	wagers := []int{10, 5, 2}
+	i := 0
+	for curr := game.Players; curr != nil; curr = curr.Tail {
+		curr.Head.Hand = hands[i]
+		game.Dealer.Bet(curr.Head, wagers[i])
+		i++
+	}
+	game.Dealer.hand = Hand{ten, {Rank: cards.Nine, Suit: cards.Clubs}}
+
+	state := game.State()
+	if state.Spots[1][0].Type != Win || state.Spots[2][0].Type != Lose || state.Spots[3][0].Type != Lose {
+		t.Fatalf("expected the state of each seat to be reported separately")
+	}
+
+	settled := make(map[int]WinType)
+	game.Listen(func(e Event) {
+		if e, ok := e.(HandSettled); ok {
+			settled[e.Hand.Seat] = e.State.Type
+		}
+	})
+	game.Dealer.Collect()
+	if settled[1] != Win || settled[3] != Lose {
+		t.Fatalf("expected a's hands at seats 1 and 3 to be settled by their own states")
+	}
+	if a.Winnings != 8 || b.Winnings != -5 {
+		t.Fatalf("expected winnings of 8 for a and -5 for b but were %d and %d", a.Winnings, b.Winnings)
+	}
+}
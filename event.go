@@ -0,0 +1,142 @@
+package blackjack
+
+import (
+	"github.com/ethanefung/cards"
+)
+
+// Event is emitted by the Game and its Dealer whenever the state of the round
+// changes. Listeners can use a type switch to handle the events they care about.
+type Event interface {
+	// Name returns the name of the event type, such as "CardDealt".
+	Name() string
+}
+
+// Listener is called with every Event emitted by the game.
+type Listener func(Event)
+
+// Channel returns a Listener that sends every event to the specified channel. The
+// game blocks until the event is received, so the channel should be buffered or
+// drained by another goroutine.
+func Channel(ch chan<- Event) Listener {
+	return func(e Event) {
+		ch <- e
+	}
+}
+
+// RoundStarted is emitted when the dealer deals the first cards of a round.
+type RoundStarted struct {
+	// Cards is the number of cards dealt to each hand and the dealer.
+	Cards int
+}
+
+// CardDealt is emitted every time a card leaves the shoe.
+type CardDealt struct {
+	// Hand is the hand the card was dealt to, or nil if the card was dealt to the
+	// dealer.
+	Hand *ListVal
+	// Card is the card that was dealt.
+	Card cards.Card
+	// FaceUp is false when the card is the dealer's hole card.
+	FaceUp bool
+	// Bet is the position of the bet of Multi-Action hands the dealer's hand the card was
+	// dealt to is drawn for, zero for the dealer's first hand.
+	Bet int
+}
+
+// BetPlaced is emitted when a wager is placed on a hand.
+type BetPlaced struct {
+	// Hand is the hand the wager was placed on.
+	Hand *ListVal
+	// Wager is the amount placed.
+	Wager int
+}
+
+// ActionTaken is emitted when a player successfully takes an action on their hand.
+type ActionTaken struct {
+	// Hand is the hand the action was taken on.
+	Hand *ListVal
+	// Action is the action that was taken.
+	Action Action
+}
+
+// HandBusted is emitted when the value of a player's hand goes over 21.
+type HandBusted struct {
+	// Hand is the hand that busted.
+	Hand *ListVal
+}
+
+// DealerRevealed is emitted when the dealer turns over their hole card.
+type DealerRevealed struct {
+	// Hand is the dealer's hand at the time of the reveal.
+	Hand Hand
+}
+
+// HandSettled is emitted for every hand when the dealer collects.
+type HandSettled struct {
+	// Hand is the hand that was settled.
+	Hand *ListVal
+	// State is the WinState of the hand.
+	State WinState
+	// Payout is the change to the player's winnings, negative when the hand lost.
+	Payout int
+	// Bet is the position of the settled bet among the bets of a Multi-Action hand, zero
+	// for the hand's Wager.
+	Bet int
+}
+
+// ShoeReshuffled is emitted when the dealer shuffles the shoe.
+type ShoeReshuffled struct {
+	// Seed is the seed the shoe was shuffled with.
+	Seed int64
+}
+
+// BackBetPlaced is emitted when a player places a back bet on another player's hand.
+type BackBetPlaced struct {
+	// Hand is the hand the back bet was placed on.
+	Hand *ListVal
+	// Bet is the back bet.
+	Bet *BackBet
+}
+
+// BackBetSettled is emitted for every back bet when the dealer collects.
+type BackBetSettled struct {
+	// Hand is the hand the back bet was placed on.
+	Hand *ListVal
+	// Bet is the back bet.
+	Bet *BackBet
+	// State is the WinState of the hand.
+	State WinState
+	// Payout is the change to the back bet player's winnings, negative when the hand
+	// lost.
+	Payout int
+}
+
+// SideBetPlaced is emitted when a player places a wager on a side bet.
+type SideBetPlaced struct {
+	// Hand is the hand the side bet was placed on.
+	Hand *ListVal
+	// Bet is the wager on the side bet.
+	Bet *SideWager
+}
+
+// SideBetSettled is emitted when a wager on a side bet is settled as the dealer
+// collects.
+type SideBetSettled struct {
+	// Hand is the hand the side bet was placed on.
+	Hand *ListVal
+	// Bet is the wager on the side bet, including its outcome and payout.
+	Bet *SideWager
+}
+
+func (RoundStarted) Name() string   { return "RoundStarted" }
+func (CardDealt) Name() string      { return "CardDealt" }
+func (BetPlaced) Name() string      { return "BetPlaced" }
+func (ActionTaken) Name() string    { return "ActionTaken" }
+func (HandBusted) Name() string     { return "HandBusted" }
+func (DealerRevealed) Name() string { return "DealerRevealed" }
+func (HandSettled) Name() string    { return "HandSettled" }
+func (ShoeReshuffled) Name() string { return "ShoeReshuffled" }
+func (BackBetPlaced) Name() string  { return "BackBetPlaced" }
+func (BackBetSettled) Name() string { return "BackBetSettled" }
+func (SideBetPlaced) Name() string  { return "SideBetPlaced" }
+func (SideBetSettled) Name() string { return "SideBetSettled" }
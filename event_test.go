@@ -0,0 +1,79 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestGameListen(t *testing.T) {
+	game := New()
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+
+	var events []Event
+	game.Listen(func(e Event) {
+		events = append(events, e)
+	})
+
+	dealer := game.Dealer
+	dealer.Stack(cards.Deck{
+		{Rank: cards.Ten, Suit: cards.Spades},
+		{Rank: cards.Nine, Suit: cards.Clubs},
+		{Rank: cards.Six, Suit: cards.Hearts},
+		{Rank: cards.Eight, Suit: cards.Diamonds},
+		{Rank: cards.King, Suit: cards.Clubs},
+	})
+	dealer.Bet(game.Players.Head, 2)
+	dealer.Deal(2, game.Players)
+	game.Start()
+	dealer.Hit()
+	dealer.Evaluate()
+	dealer.Play()
+	dealer.Collect()
+
+	names := []string{
+		"BetPlaced",
+		"RoundStarted",
+		"CardDealt", "CardDealt", "CardDealt", "CardDealt",
+		"ActionTaken", "CardDealt", "HandBusted",
+		"DealerRevealed",
+		"HandSettled",
+	}
+	if len(events) != len(names) {
+		t.Fatalf("expected %d events to be emitted but got %d: %v", len(names), len(events), events)
+	}
+	for i, name := range names {
+		if events[i].Name() != name {
+			t.Fatalf("expected event %d to be %s but was %s", i, name, events[i].Name())
+		}
+	}
+
+	hole := events[3].(CardDealt)
+	if hole.Hand != nil || hole.FaceUp {
+		t.Fatalf("expected the dealer's first card to be dealt face down")
+	}
+	upcard := events[5].(CardDealt)
+	if upcard.Hand != nil || !upcard.FaceUp {
+		t.Fatalf("expected the dealer's second card to be dealt face up")
+	}
+
+	settled := events[len(events)-1].(HandSettled)
+	if settled.State.Type != Bust || settled.Payout != -2 {
+		t.Fatalf("expected the busted hand to be settled with a payout of -2 but got %v with %d", settled.State.Type, settled.Payout)
+	}
+}
+
+func TestChannel(t *testing.T) {
+	game := New()
+	ch := make(chan Event, 1)
+	game.Listen(Channel(ch))
+
+	game.Dealer.UseDecks(1)
+	game.Dealer.Shuffle(4)
+
+	e := <-ch
+	if reshuffled, ok := e.(ShoeReshuffled); !ok || reshuffled.Seed != 4 {
+		t.Fatalf("expected a ShoeReshuffled event with seed 4 but got %v", e)
+	}
+}
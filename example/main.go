@@ -51,19 +51,11 @@ func printState(state blackjack.GameState, g *blackjack.Game) {
 	}
 	b.WriteRune('\n')
 
-    var i int
-    var last *blackjack.Player
 	for curr := g.Players; curr != nil; curr = curr.Tail {
 		val := curr.Head
 		b.WriteString(val.Player.String() + ": ")
 		if g.PlayersPlayed() {
-            if last == val.Player {
-                i++
-            } else {
-                last = val.Player
-                i = 0
-            }
-			b.WriteString(state.Players[val.Player][i].Type.String())
+			b.WriteString(state.Hands[val.ID].State.Type.String())
 		} else if curr.Head == g.Current.Head {
             b.WriteString("Playing")
         }
@@ -0,0 +1,26 @@
+package blackjack
+
+// doubleExposure is the behaviour of DoubleExposure.
+type doubleExposure struct {
+	standard
+}
+
+func (doubleExposure) hidden(i int) bool {
+	return false
+}
+
+// outcome loses every tie to the dealer but a natural against a natural. A natural beats
+// any other 21.
+func (s doubleExposure) outcome(val *ListVal, dealer Hand) WinType {
+	switch {
+	case val.natural() && dealer.natural():
+		return Push
+	case val.natural():
+		return Win
+	case dealer.natural():
+		return Lose
+	case val.Hand.Value() == dealer.Value():
+		return Lose
+	}
+	return s.standard.outcome(val, dealer)
+}
@@ -0,0 +1,88 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestDoubleExposureDeal(t *testing.T) {
+	game := New()
+	game.Rules.Variant = DoubleExposure
+	game.AddPlayer(NewPlayer("a"))
+	hidden := 0
+	game.Listen(func(e Event) {
+		if dealt, ok := e.(CardDealt); ok && !dealt.FaceUp {
+			hidden++
+		}
+	})
+	game.Dealer.UseDecks(1)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if hidden != 0 {
+		t.Fatalf("expected every card to be dealt face up but %d were not", hidden)
+	}
+	if len(game.Dealer.ShowHand()) != 2 {
+		t.Fatalf("expected the dealer to show both cards while players play their hands")
+	}
+	if view := game.PublicView(); view.Dealer.Hidden != 0 || len(view.Dealer.Cards) != 2 {
+		t.Fatalf("expected the view to show both of the dealer's cards")
+	}
+}
+
+func TestDoubleExposureTwentyOnePlusThree(t *testing.T) {
+	game := New()
+	game.Rules.Variant = DoubleExposure
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.SideBet(val, TwentyOnePlusThree{}, 5)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Seven, Suit: cards.Spades},
+		{Rank: cards.King, Suit: cards.Hearts},
+		{Rank: cards.Eight, Suit: cards.Spades},
+		{Rank: cards.Nine, Suit: cards.Spades},
+	})
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+	game.Dealer.Stay()
+	game.Dealer.Play()
+	game.Dealer.Collect()
+
+	// the 7 and 8 make a straight flush with the dealer's upcard, the 9 of spades, rather
+	// than with the king dealt first
+	if outcome := val.SideBets[0].Outcome; outcome != StraightFlush || a.Winnings != 190 {
+		t.Fatalf("expected a straight flush with the upcard but got %q and winnings of %d", outcome, a.Winnings)
+	}
+}
+
+func TestDoubleExposureOutcomes(t *testing.T) {
+	card := func(r cards.Rank) cards.Card {
+		return cards.Card{Rank: r, Suit: cards.Hearts}
+	}
+	natural := Hand{card(cards.Ace), card(cards.Queen)}
+	tests := []struct {
+		hand    Hand
+		dealer  Hand
+		outcome WinType
+		payout  int
+	}{
+		{Hand{card(cards.King), card(cards.Nine)}, Hand{card(cards.Ten), card(cards.Nine)}, Lose, -10},
+		{Hand{card(cards.Five), card(cards.Six), card(cards.Jack)}, Hand{card(cards.Four), card(cards.Seven), card(cards.Jack)}, Lose, -10},
+		{natural, natural, Push, 0},
+		{natural, Hand{card(cards.Four), card(cards.Seven), card(cards.Jack)}, Win, 10},
+		{Hand{card(cards.Five), card(cards.Six), card(cards.Jack)}, natural, Lose, -10},
+		{Hand{card(cards.King), card(cards.Nine)}, Hand{card(cards.Ten), card(cards.Six), card(cards.Seven)}, Win, 10},
+	}
+
+	v := Rules{Variant: DoubleExposure}.variant()
+	for _, test := range tests {
+		val := &ListVal{Hand: test.hand}
+		outcome := v.outcome(val, test.dealer)
+		if payout := v.payout(val, outcome, 10); outcome != test.outcome || payout != test.payout {
+			t.Errorf("expected %v against %v to %s paying %d but was %s paying %d", test.hand, test.dealer, test.outcome, test.payout, outcome, payout)
+		}
+	}
+}
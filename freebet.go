@@ -0,0 +1,29 @@
+package blackjack
+
+// freeBet is the behaviour of FreeBet.
+type freeBet struct {
+	standard
+}
+
+// outcome pushes every hand against a dealer's 22.
+func (s freeBet) outcome(val *ListVal, dealer Hand) WinType {
+	if dealer.Value() == 22 {
+		return Push
+	}
+	return s.standard.outcome(val, dealer)
+}
+
+// free doubles a hard 9, 10 or 11 of two cards, and splits every pair but tens.
+func (freeBet) free(val *ListVal, a Action) bool {
+	h := val.Hand
+	if len(h) != 2 {
+		return false
+	}
+	switch a {
+	case Double:
+		return !h.IsSoft() && h.Value() >= 9 && h.Value() <= 11
+	case Split:
+		return h.HasPair() && cardValues[h[0].Rank] != 10
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+// freeBetRound starts a round of Free Bet with a single hand wagering 10 that is dealt
+// the specified cards, while the dealer is dealt a 9 and an 8.
+func freeBetRound(first, second cards.Rank, rest ...cards.Rank) (*Game, *Player) {
+	game := New()
+	game.Rules.Variant = FreeBet
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	deck := cards.Deck{{Rank: first}, {Rank: cards.Nine}, {Rank: second}, {Rank: cards.Eight}}
+	for _, r := range rest {
+		deck = append(deck, cards.Card{Rank: r})
+	}
+	game.Dealer.Stack(deck)
+	game.Dealer.Bet(game.Players.Head, 10)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+	return game, a
+}
+
+func TestFreeBetDouble(t *testing.T) {
+	tests := []struct {
+		draw     cards.Rank
+		winnings int
+	}{
+		{cards.King, 20},
+		{cards.Two, -10},
+	}
+	for _, test := range tests {
+		game, a := freeBetRound(cards.Five, cards.Six, test.draw)
+		val := game.Players.Head
+		if !game.Dealer.Double() || val.Wager != 10 || val.Free != 10 {
+			t.Fatalf("expected the house to put up the double of a hard 11 but wager was %d and free %d", val.Wager, val.Free)
+		}
+		game.Dealer.Play()
+		game.Dealer.Collect()
+		if a.Winnings != test.winnings {
+			t.Errorf("expected a free double drawing %s to change winnings by %d but was %d", test.draw, test.winnings, a.Winnings)
+		}
+	}
+
+	game, _ := freeBetRound(cards.Ace, cards.Six, cards.Two)
+	if game.Dealer.Double(); game.Players.Head.Wager != 20 || game.Players.Head.Free != 0 {
+		t.Fatalf("expected a soft 17 to double with the player's own wager")
+	}
+}
+
+func TestFreeBetSplit(t *testing.T) {
+	game, a := freeBetRound(cards.Eight, cards.Eight, cards.Three, cards.Two)
+	if !game.Dealer.Split() {
+		t.Fatalf("expected the dealer to split a pair of eights")
+	}
+	next := game.Hands().At(1)
+	if next.Wager != 0 || next.Free != 10 {
+		t.Fatalf("expected the house to put up the wager of the split hand but wager was %d and free %d", next.Wager, next.Free)
+	}
+	game.Dealer.Stay()
+	game.Dealer.Stay()
+	game.Dealer.Play()
+	game.Dealer.Collect()
+	if a.Winnings != -10 {
+		t.Fatalf("expected only the player's own wager to be lost but winnings were %d", a.Winnings)
+	}
+
+	game, _ = freeBetRound(cards.King, cards.King, cards.Three, cards.Two)
+	if game.Dealer.Split(); game.Hands().At(1).Wager != 10 {
+		t.Fatalf("expected a pair of tens to be split with the player's own wager")
+	}
+}
+
+func TestFreeBetDealer22(t *testing.T) {
+	v := Rules{Variant: FreeBet}.variant()
+	dealer := Hand{{Rank: cards.Six}, {Rank: cards.Six}, {Rank: cards.King}}
+	if outcome := v.outcome(&ListVal{Hand: Hand{{Rank: cards.Five}, {Rank: cards.Seven}}}, dealer); outcome != Push {
+		t.Fatalf("expected a dealer's 22 to push but was %s", outcome)
+	}
+}
+
+func TestFreeBetSurrender(t *testing.T) {
+	game, a := freeBetRound(cards.Eight, cards.Eight, cards.Three, cards.Two)
+	game.Dealer.Split()
+	game.Dealer.Stay()
+	if game.Dealer.Surrender() {
+		t.Fatalf("expected the dealer to refuse to surrender a hand with a free wager")
+	}
+	if game.Hands().At(1).Free != 10 || a.Winnings != 0 {
+		t.Fatalf("expected the free wager to be kept when the surrender is refused")
+	}
+}
+
+func TestFreeBetPaidDoubleOfFreeSplit(t *testing.T) {
+	game, a := freeBetRound(cards.Eight, cards.Eight, cards.Three, cards.Ace, cards.Two)
+	game.Dealer.Split()
+	game.Dealer.Stay()
+
+	next := game.Hands().At(1)
+	if !game.Dealer.Double() || next.Wager != 10 || next.Free != 10 {
+		t.Fatalf("expected the player to pay the free stake to double a soft 19 but wager was %d and free %d", next.Wager, next.Free)
+	}
+	game.Dealer.Play()
+	game.Dealer.Collect()
+	if a.Winnings != 10 {
+		t.Fatalf("expected the 11 to lose 10 and the doubled 21 to win 20 but winnings were %d", a.Winnings)
+	}
+}
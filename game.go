@@ -5,6 +5,10 @@ A simple library of reasonable and extendable utilities to build blackjack appli
 */
 package blackjack
 
+import (
+	"fmt"
+)
+
 // WinType is the state used to determine if a player or dealer has
 // won in the round.
 type WinType int
@@ -20,6 +24,9 @@ const (
 	Bust
 	// Push means the value of the hand is the same as the dealers.
 	Push
+	// Charlie is a winning WinType that means the hand reached the number of cards of
+	// the game's Charlie rule without busting.
+	Charlie
 )
 
 type ListType int
@@ -31,6 +38,22 @@ const (
 
 //go:generate stringer -type=WinType
 
+// MarshalText encodes the WinType as its name.
+func (t WinType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes a WinType from its name.
+func (t *WinType) UnmarshalText(text []byte) error {
+	for i := WinType(0); int(i) < len(_WinType_index)-1; i++ {
+		if i.String() == string(text) {
+			*t = i
+			return nil
+		}
+	}
+	return fmt.Errorf("blackjack: unknown win type %q", text)
+}
+
 // WinState is the state of a player or dealer and the value of the hand.
 type WinState struct {
 	// Value is the numeric value of the hand the state represents.
@@ -48,11 +71,35 @@ able to play multiple hands. So how do we represent the states of each hand?
 type GameState struct {
 	// Dealer is the WinState of the game's dealer.
 	Dealer WinState
+	// DealerHand is the dealer's face up cards while players are playing their hands,
+	// and the dealer's final hand once every hand has been played.
+	DealerHand Hand
 	// Players is a map each win state for each hand of the player
 	Players map[*Player][]WinState
+	// Spots is a map of each win state for each hand played from a seat, in the order
+	// the hands are played.
+	Spots map[int][]WinState
+	// Hands is a map of the state of each hand by the ID of the hand.
+	Hands map[int]HandState
+	// SideBets is a map of the side bets placed on each hand by the ID of the hand.
+	SideBets map[int][]SideBetResult
+}
+
+// HandState is the WinState of a single hand along with the hand it belongs to.
+type HandState struct {
+	// Hand is the hand.
+	Hand *ListVal
+	// Player is the owner of the hand.
+	Player *Player
+	// State is the WinState of the hand.
+	State WinState
+	// Bets are the WinStates of every bet of a Multi-Action hand, against the dealer's
+	// hand drawn for the bet, starting with State. Bets is nil for any other hand.
+	Bets []WinState
 }
 
-// PlayersList is a link list of players.
+// PlayersList is a link list of players. The hands of a game are kept in Hands, which
+// links them into a PlayersList for existing callers.
 type PlayersList struct {
 	// Head will always represent a Player struct.
 	Head *ListVal
@@ -63,32 +110,65 @@ type PlayersList struct {
 }
 
 type ListVal struct {
+	// ID identifies the hand within its game. The game assigns an ID when the hand is
+	// added, including when the hand is split from another, and never reuses it.
+	ID int
 	// Player represents the owner of the hand.
 	Player *Player
 	// Hand represents the cards that dealer is comparing.
 	Hand Hand
 	// Wager represents how much the hand is worth to the players winnings.
 	Wager int
+	// Extra represents the wagers after Wager of a Multi-Action hand, each played
+	// against its own dealer hand.
+	Extra []int
+	// Free represents the wager put up by the house for a free double or split, which
+	// is only paid to the players winnings when the hand wins.
+	Free int
 	// Split represents whether this ListVal was added during the game.
 	Split bool
+	// Doubled represents whether the wager of the hand was doubled during the round.
+	Doubled bool
+	// Second represents whether this ListVal is the second hand of a seat in Blackjack
+	// Switch, which is added when the round is dealt.
+	Second bool
+	// Switched represents whether the second card of the hand was switched with the
+	// other hand of its seat in Blackjack Switch.
+	Switched bool
+	// Seat is the seat the hand is played from, zero at a table without seats.
+	Seat int
+	// BackBets are the wagers placed on the hand by other players.
+	BackBets []*BackBet
+	// SideBets are the wagers on side bets placed on the hand by its player.
+	SideBets []*SideWager
 }
 
 // Len returns the length of the players list.
 func (p *PlayersList) Len() int {
-	if p.Tail == nil {
-		return 1
+	n := 0
+	for curr := p; curr != nil; curr = curr.Tail {
+		n++
 	}
-	return 1 + p.Tail.Len()
+	return n
 }
 
 // Game is aggregate struct of PlayersList and Dealer.
 type Game struct {
 	// Players is the PlayersList that represents all the players who are current in game.
+	// Players is linked from Game.Hands, which should be preferred.
 	Players *PlayersList
 	// Current is the PlayersList that represents the player who is currently playing their turn.
+	// Current is linked from the cursor of Game.Hands, which should be preferred.
 	Current *PlayersList
 	// Dealer is the controller of the Game.
 	Dealer *Dealer
+	// Rules are the table rules the game is played with.
+	Rules Rules
+
+	hands     Hands
+	seats     []*Player
+	handID    int
+	listeners []Listener
 }
 
 // New returns an instance of Game.
@@ -99,75 +179,77 @@ func New() *Game {
 	return game
 }
 
+// Listen registers the specified listener to be called with every Event emitted by the
+// game and its dealer.
+func (g *Game) Listen(l Listener) {
+	g.listeners = append(g.listeners, l)
+}
+
+func (g *Game) emit(e Event) {
+	for _, l := range g.listeners {
+		l(e)
+	}
+}
+
 // AddPlayer appends a list value to the end of Game.Players.
 //
 func (g *Game) AddPlayer(p *Player) {
 	// add player will create a new ListVal for the player
-	val := &ListVal{Player: p}
-	if g.Players == nil {
-		g.Players = &PlayersList{Head: val}
-	} else {
-		curr := g.Players
-		for curr.Tail != nil {
-			curr = curr.Tail
-		}
-		curr.Tail = &PlayersList{Head: val}
-	}
+	g.add(&ListVal{Player: p})
+}
+
+// nextHandID returns the ID of the next hand added to the game.
+func (g *Game) nextHandID() int {
+	g.handID++
+	return g.handID
+}
+
+// Hand returns the hand in Game.Players with the specified ID, or nil if there is no
+// such hand.
+func (g *Game) Hand(id int) *ListVal {
+	return g.Hands().ByID(id)
+}
+
+// add appends the list value to the end of Game.Players, assigning it an ID if it has
+// none.
+func (g *Game) add(val *ListVal) {
+	g.Hands().Append(val)
 }
 
 // RemovePlayer removes all list values with the specified player reference
 func (g *Game) RemovePlayer(p *Player) {
-	for curr := g.Players; curr != nil && curr.Head.Player == p; curr = curr.Tail {
-		g.Players = curr.Tail
-	}
-
-	for curr := g.Players; curr.Tail != nil; curr = curr.Tail {
-		if curr.Tail.Head.Player == p && curr.Tail.Tail == nil {
-			curr.Tail = nil
-			break
-		} else if curr.Tail.Head.Player == p {
-			curr.Tail = curr.Tail.Tail
-		}
+	hands := g.Hands()
+	for _, val := range hands.ByPlayer(p) {
+		hands.Remove(val)
 	}
 }
 
 // RemoveListVal removes the specified node within the players list.
 func (g *Game) RemoveListVal(val *ListVal) {
-	curr := g.Players
-	if curr != nil && curr.Head == val {
-		g.Players = curr.Tail
-		return
-	}
-	for curr = g.Players; curr.Tail != nil; curr = curr.Tail {
-		if curr.Tail.Head == val && curr.Tail.Tail == nil {
-			curr.Tail = nil
-			return
-		} else if curr.Tail.Head == val {
-			curr.Tail = curr.Tail.Tail
-			return
-		}
-	}
+	g.Hands().Remove(val)
+}
+
+// position returns the index of the specified list value within Game.Players, or -1 if
+// the list value is not in the game.
+func (g *Game) position(val *ListVal) int {
+	return g.Hands().Index(val)
+}
+
+// current returns the list value whose turn it is, or nil if no hand is being played.
+func (g *Game) current() *ListVal {
+	return g.Hands().Current()
 }
 
 // Start assigns the current Game.Players list to Game.Current (necessary for dealer to
 // know which player to deal to).
 func (g *Game) Start() bool {
-	if g.Players == nil {
-		return false
-	}
-	g.Current = g.Players
-	return true
+	return g.Hands().Start()
 }
 
 // EndPlayerTurn will change Game.Current to the next player within the Game.Players
 // list. May also assign Game.Current to nil if there are no more players.
 func (g *Game) EndPlayerTurn() bool {
-	if g.Current.Tail == nil {
-		g.Current = nil
-		return false
-	}
-	g.Current = g.Current.Tail
-	return true
+	return g.Hands().Next()
 }
 
 // State returns a GameState struct which changes depending on whether the game is done.
@@ -183,41 +265,75 @@ func (g *Game) State() GameState {
 		dealerState.Value = dealer.hand.Value()
 	}
 
-	winStates := make(map[*Player][]WinState, g.Players.Len())
+	vals := g.Hands().All()
+	winStates := make(map[*Player][]WinState, len(vals))
+	spots := make(map[int][]WinState)
+	hands := make(map[int]HandState)
+	sideBets := make(map[int][]SideBetResult)
 
-	for list := g.Players; list != nil; list = list.Tail {
-		listVal := list.Head
+	for _, listVal := range vals {
+		for _, w := range listVal.SideBets {
+			sideBets[listVal.ID] = append(sideBets[listVal.ID], w.Result())
+		}
 		player := listVal.Player
 		hand := listVal.Hand
 		winState := WinState{Value: hand.Value()}
-		playerHand, dealerHand := hand.Value(), dealer.hand.Value()
+		var bets []WinState
+		if len(listVal.Extra) > 0 {
+			bets = make([]WinState, 1+len(listVal.Extra))
+			for i := range bets {
+				bets[i] = winState
+			}
+		}
 
 		if !done {
 			winStates[player] = append(winStates[player], winState)
+			if listVal.Seat != 0 {
+				spots[listVal.Seat] = append(spots[listVal.Seat], winState)
+			}
+			hands[listVal.ID] = HandState{Hand: listVal, Player: player, State: winState, Bets: bets}
 			continue
 		}
 		// game is done
-		if playerHand > 21 {
-			winState.Type = Bust
-		} else if playerHand == dealerHand {
-			winState.Type = Push
-		} else if playerHand > dealerHand || dealerHand > 21 {
-			winState.Type = Win
-		} else {
-			winState.Type = Lose
+		winState.Type = g.outcome(listVal, dealer.hand)
+		for i := range bets {
+			if i == 0 {
+				bets[i] = winState
+			} else if i <= len(dealer.hands) {
+				bets[i].Type = g.outcome(listVal, dealer.hands[i-1])
+			}
 		}
 
 		winStates[player] = append(winStates[player], winState)
+		if listVal.Seat != 0 {
+			spots[listVal.Seat] = append(spots[listVal.Seat], winState)
+		}
+		hands[listVal.ID] = HandState{Hand: listVal, Player: player, State: winState, Bets: bets}
 	}
 
 	return GameState{
-		Dealer:  dealerState,
-		Players: winStates,
+		Dealer:     dealerState,
+		DealerHand: append(Hand{}, dealer.ShowHand()...),
+		Players:    winStates,
+		Spots:      spots,
+		Hands:      hands,
+		SideBets:   sideBets,
+	}
+}
+
+// outcome returns the WinType of a hand that has been played against the specified
+// dealer's hand.
+func (g *Game) outcome(val *ListVal, dealer Hand) WinType {
+	if val.Hand.Value() > 21 {
+		return Bust
+	} else if g.Rules.charlie(val.Hand) {
+		return Charlie
 	}
+	return g.Rules.variant().outcome(val, dealer)
 }
 
 // PlayersPlayed returns false if there are still players who have yet to take their
 // turn for the round.
 func (g *Game) PlayersPlayed() bool {
-	return g.Current == nil
+	return g.current() == nil
 }
@@ -71,6 +71,14 @@ func TestRemovePlayer(t *testing.T) {
 	if game.Players.Len() != 2 {
 		t.Fatalf("Attempted to remove player a, but was unsuccessful")
 	}
+
+	game = New()
+
+	game.AddPlayer(a)
+	game.RemovePlayer(a)
+	if game.Players != nil {
+		t.Fatalf("Attempted to remove the only player, but was unsuccessful")
+	}
 }
 
 func TestGameState(t *testing.T) {
@@ -127,3 +135,50 @@ func TestGameState(t *testing.T) {
 		t.Fatalf("expected that player 'b' to be Undetermined, but players state was %s", states[b][0].Type.String())
 	}
 }
+
+func TestGameHandIDs(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	game.AddPlayer(b)
+
+	game.Dealer.UseDecks(1)
+	game.Dealer.Shuffle(1)
+	game.Players.Head.Hand = Hand{{Rank: cards.Eight}, {Rank: cards.Eight}}
+	game.Start()
+	game.Dealer.Split()
+
+	seen := make(map[int]bool)
+	for curr := game.Players; curr != nil; curr = curr.Tail {
+		id := curr.Head.ID
+		if id == 0 || seen[id] {
+			t.Fatalf("expected every hand to have a distinct ID but found %d twice", id)
+		}
+		seen[id] = true
+		if game.Hand(id) != curr.Head {
+			t.Fatalf("expected hand %d to be found by its ID", id)
+		}
+	}
+
+	game.Players.Head.Hand = Hand{{Rank: cards.Eight}, {Rank: cards.Six}, {Rank: cards.Ten}}
+	game.Players.Tail.Head.Hand = Hand{{Rank: cards.Eight}, {Rank: cards.Nine}}
+	game.Dealer.hand = Hand{{Rank: cards.Ten}, {Rank: cards.Eight}}
+	game.Current = nil
+	hands := game.State().Hands
+	if len(hands) != 3 {
+		t.Fatalf("expected the state of three hands but was %d", len(hands))
+	}
+	first, second := game.Players.Head, game.Players.Tail.Head
+	if hands[first.ID].State.Type != Bust || hands[second.ID].State.Type != Lose {
+		t.Fatalf("expected the state of each split hand to be keyed by its own ID")
+	}
+	if hands[game.Players.Tail.Tail.Head.ID].Player != b {
+		t.Fatalf("expected the state of b's hand to be owned by b")
+	}
+
+	game.RemovePlayer(a)
+	game.AddPlayer(a)
+	if game.Players.Tail.Head.ID <= second.ID {
+		t.Fatalf("expected a hand added later to have a new ID")
+	}
+}
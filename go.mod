@@ -3,3 +3,5 @@ module github.com/ethanefung/blackjack
 go 1.18
 
 require github.com/ethanefung/cards v0.1.0
+
+require github.com/gorilla/websocket v1.5.0
@@ -67,3 +67,21 @@ func (h *Hand) HasPair() bool {
 	}
 	return false
 }
+
+// IsSoft will return true if the hand has an ace that is counted as 11.
+func (h *Hand) IsSoft() bool {
+	var val int
+	for _, card := range *h {
+		if card.Rank == cards.Ace {
+			val++
+		} else {
+			val += cardValues[card.Rank]
+		}
+	}
+	return h.HasAce() && val+10 <= 21
+}
+
+// natural returns true if the hand is a two card 21.
+func (h *Hand) natural() bool {
+	return len(*h) == 2 && h.Value() == 21
+}
@@ -41,3 +41,24 @@ func TestHandValue(t *testing.T) {
 		t.Fatalf("expected hand to have a value of 22, but got %d", hand.Value())
 	}
 }
+
+func TestHandIsSoft(t *testing.T) {
+	hand := Hand{
+		{Rank: cards.Ace},
+		{Rank: cards.Six},
+	}
+	if !hand.IsSoft() {
+		t.Fatalf("expected an ace and a six to be a soft hand")
+	}
+	hand.Draw(cards.Card{Rank: cards.Ten})
+	if hand.IsSoft() {
+		t.Fatalf("expected an ace, six and ten to be a hard hand")
+	}
+	hand = Hand{
+		{Rank: cards.Ten},
+		{Rank: cards.Seven},
+	}
+	if hand.IsSoft() {
+		t.Fatalf("expected a hand without an ace to be a hard hand")
+	}
+}
@@ -0,0 +1,215 @@
+package blackjack
+
+// Hands is the collection of hands at the table in the order they are played, along with
+// a cursor on the hand whose turn it is. The cursor stays on the same hand as hands are
+// inserted and removed around it.
+//
+// The hands of a Game are also linked into Game.Players and Game.Current for existing
+// callers. Each hand keeps its node of the list, which is relinked whenever the hands
+// change, and a game whose Players or Current has been reassigned takes the reassigned
+// list as its hands.
+type Hands struct {
+	game    *Game
+	vals    []*ListVal
+	nodes   []*PlayersList
+	current *ListVal
+
+	// players and turn are the lists last linked into the game.
+	players *PlayersList
+	turn    *PlayersList
+}
+
+// Hands returns the hands of the game.
+func (g *Game) Hands() *Hands {
+	h := &g.hands
+	h.game = g
+	if g.Players != h.players || g.Current != h.turn {
+		h.adopt(g.Players, g.Current)
+	}
+	return h
+}
+
+// adopt replaces the hands with those of the list, giving an ID to any hand without one.
+func (h *Hands) adopt(players, current *PlayersList) {
+	h.vals, h.nodes = nil, nil
+	h.current = nil
+	for curr := players; curr != nil; curr = curr.Tail {
+		if curr.Head.ID == 0 {
+			curr.Head.ID = h.game.nextHandID()
+		}
+		h.vals = append(h.vals, curr.Head)
+		h.nodes = append(h.nodes, curr)
+	}
+	if current != nil && h.Index(current.Head) >= 0 {
+		h.current = current.Head
+	}
+	h.players, h.turn = players, current
+}
+
+// link relinks the nodes of the hands into Game.Players and Game.Current.
+func (h *Hands) link() {
+	if h.game == nil {
+		return
+	}
+	var players, turn *PlayersList
+	for i := len(h.nodes) - 1; i >= 0; i-- {
+		h.nodes[i].Tail = players
+		players = h.nodes[i]
+		if h.vals[i] == h.current {
+			turn = players
+		}
+	}
+	h.players, h.turn = players, turn
+	h.game.Players, h.game.Current = players, turn
+}
+
+// All returns every hand in the order they are played.
+func (h *Hands) All() []*ListVal {
+	return append([]*ListVal{}, h.vals...)
+}
+
+// Len returns the number of hands.
+func (h *Hands) Len() int {
+	return len(h.vals)
+}
+
+// At returns the hand at the specified position, or nil if there is no such position.
+func (h *Hands) At(i int) *ListVal {
+	if i < 0 || i >= len(h.vals) {
+		return nil
+	}
+	return h.vals[i]
+}
+
+// Index returns the position of the hand, or -1 if the hand is not in the collection.
+func (h *Hands) Index(val *ListVal) int {
+	for i, v := range h.vals {
+		if v == val {
+			return i
+		}
+	}
+	return -1
+}
+
+// ByID returns the hand with the specified ID, or nil if there is no such hand.
+func (h *Hands) ByID(id int) *ListVal {
+	for _, v := range h.vals {
+		if v.ID == id {
+			return v
+		}
+	}
+	return nil
+}
+
+// ByPlayer returns the hands of the player in the order they are played.
+func (h *Hands) ByPlayer(p *Player) []*ListVal {
+	var vals []*ListVal
+	for _, v := range h.vals {
+		if v.Player == p {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+// Append adds the hand after every other hand.
+func (h *Hands) Append(val *ListVal) {
+	h.Insert(len(h.vals), val)
+}
+
+// Insert adds the hand at the specified position, moving the hands from that position
+// on back by one. Hands added to a game without an ID are given the game's next ID.
+// Insert returns false if the hand is nil or already in the collection, or the position
+// is out of range.
+func (h *Hands) Insert(i int, val *ListVal) bool {
+	if val == nil || i < 0 || i > len(h.vals) || h.Index(val) >= 0 {
+		return false
+	}
+	if val.ID == 0 && h.game != nil {
+		val.ID = h.game.nextHandID()
+	}
+	h.vals = append(h.vals, nil)
+	copy(h.vals[i+1:], h.vals[i:])
+	h.vals[i] = val
+	h.nodes = append(h.nodes, nil)
+	copy(h.nodes[i+1:], h.nodes[i:])
+	h.nodes[i] = &PlayersList{Head: val}
+	h.link()
+	return true
+}
+
+// InsertAfter adds the next hand directly after the specified hand, as when a hand is
+// split. InsertAfter returns false if the specified hand is not in the collection.
+func (h *Hands) InsertAfter(val, next *ListVal) bool {
+	i := h.Index(val)
+	if i < 0 {
+		return false
+	}
+	return h.Insert(i+1, next)
+}
+
+// Remove removes the hand. If it is the hand whose turn it is, the turn passes to the
+// hand after it. Remove returns false if the hand is not in the collection.
+func (h *Hands) Remove(val *ListVal) bool {
+	i := h.Index(val)
+	if i < 0 {
+		return false
+	}
+	if val == h.current {
+		h.current = h.At(i + 1)
+	}
+	h.vals = append(h.vals[:i], h.vals[i+1:]...)
+	h.nodes = append(h.nodes[:i], h.nodes[i+1:]...)
+	h.link()
+	return true
+}
+
+// Clear removes every hand.
+func (h *Hands) Clear() {
+	h.vals, h.nodes = nil, nil
+	h.current = nil
+	h.link()
+}
+
+// Current returns the hand whose turn it is, or nil if no hand is being played.
+func (h *Hands) Current() *ListVal {
+	return h.current
+}
+
+// SetCurrent gives the turn to the specified hand. SetCurrent returns false if the hand
+// is not in the collection.
+func (h *Hands) SetCurrent(val *ListVal) bool {
+	if h.Index(val) < 0 {
+		return false
+	}
+	h.current = val
+	h.link()
+	return true
+}
+
+// Start gives the turn to the first hand. Start returns false if there are no hands.
+func (h *Hands) Start() bool {
+	if len(h.vals) == 0 {
+		return false
+	}
+	h.current = h.vals[0]
+	h.link()
+	return true
+}
+
+// Next passes the turn to the hand after the current hand. Next returns false once there
+// are no more hands to play.
+func (h *Hands) Next() bool {
+	if h.current == nil {
+		return false
+	}
+	h.current = h.At(h.Index(h.current) + 1)
+	h.link()
+	return h.current != nil
+}
+
+// Stop ends the turn of the current hand without passing it on.
+func (h *Hands) Stop() {
+	h.current = nil
+	h.link()
+}
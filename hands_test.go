@@ -0,0 +1,85 @@
+package blackjack
+
+import (
+	"testing"
+)
+
+func TestHands(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	game.AddPlayer(b)
+	game.AddPlayer(a)
+	hands := game.Hands()
+
+	if hands.Len() != 3 || len(hands.ByPlayer(a)) != 2 || hands.ByPlayer(a)[1] != hands.At(2) {
+		t.Fatalf("expected a to have the first and last of three hands")
+	}
+	first := hands.At(0)
+	split := &ListVal{Player: a, Split: true}
+	if !hands.InsertAfter(first, split) || hands.Index(split) != 1 || split.ID == 0 {
+		t.Fatalf("expected the split hand to be given an ID and follow the first hand")
+	}
+	if hands.InsertAfter(&ListVal{}, &ListVal{}) || hands.Insert(0, split) {
+		t.Fatalf("expected hands to only be inserted once after a hand of the collection")
+	}
+
+	all := hands.All()
+	i := 0
+	for curr := game.Players; curr != nil; curr = curr.Tail {
+		if curr.Head != all[i] {
+			t.Fatalf("expected Game.Players to be linked in the order of the hands")
+		}
+		i++
+	}
+	if i != len(all) {
+		t.Fatalf("expected Game.Players to link %d hands but linked %d", len(all), i)
+	}
+}
+
+func TestHandsCursor(t *testing.T) {
+	game := New()
+	a, b, c := NewPlayer("a"), NewPlayer("b"), NewPlayer("c")
+	game.AddPlayer(a)
+	game.AddPlayer(b)
+	game.AddPlayer(c)
+	hands := game.Hands()
+
+	if hands.Current() != nil || !hands.Start() || !hands.Next() {
+		t.Fatalf("expected the turn to start on the first hand and pass to the second")
+	}
+	current := hands.Current()
+	hands.InsertAfter(hands.At(0), &ListVal{Player: a})
+	hands.Remove(hands.At(0))
+	if hands.Current() != current || game.Current.Head != current {
+		t.Fatalf("expected the turn to stay on b's hand as hands are inserted and removed")
+	}
+	hands.Remove(current)
+	if hands.Current() == nil || hands.Current().Player != c {
+		t.Fatalf("expected the turn to pass to c when b's hand is removed")
+	}
+	if hands.Next() || game.Current != nil || !game.PlayersPlayed() {
+		t.Fatalf("expected every hand to have played")
+	}
+
+	hands.Clear()
+	if game.Players != nil || game.Start() {
+		t.Fatalf("expected a game without hands not to start")
+	}
+}
+
+func TestHandsAdopt(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.Players = &PlayersList{Head: &ListVal{Player: a}, Tail: &PlayersList{Head: &ListVal{Player: b}}}
+	game.Current = game.Players.Tail
+
+	hands := game.Hands()
+	if hands.Len() != 2 || hands.Current() != game.Players.Tail.Head || hands.At(1).ID == 0 {
+		t.Fatalf("expected a reassigned list to be taken as the hands with b to play")
+	}
+	game.Current = nil
+	if game.Hands().Current() != nil {
+		t.Fatalf("expected clearing Game.Current to end the turn")
+	}
+}
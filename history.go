@@ -0,0 +1,317 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethanefung/cards"
+)
+
+// HistoryVersion is the version of the hand history format written by a Recorder.
+// Version 2 records the back bets and side bets placed on each hand, along with the
+// jackpots of progressive wagers.
+const HistoryVersion = 2
+
+// History is a serializable record of every round played in a game.
+type History struct {
+	// Version is the version of the format the history was written in.
+	Version int `json:"version"`
+	// Rounds are the rounds of the game in the order they were played.
+	Rounds []*Round `json:"rounds"`
+}
+
+// Round is the record of a single round: the shoe it was dealt from, the hands at the
+// table, every action taken and how each hand was settled.
+type Round struct {
+	// Rules are the table rules the round was played with.
+	Rules Rules `json:"rules"`
+	// Seed is the seed the shoe was last shuffled with before the round.
+	Seed int64 `json:"seed"`
+	// Shoe is the cards that were yet to be dealt when the round started.
+	Shoe cards.Deck `json:"shoe"`
+	// Cards is the number of cards initially dealt to each hand.
+	Cards int `json:"cards"`
+	// Players are the players seated at the table.
+	Players []RecordedPlayer `json:"players"`
+	// Hands are the hands dealt at the start of the round in the order they were dealt.
+	Hands []RecordedHand `json:"hands"`
+	// Steps are the actions taken by the players in the order they were taken.
+	Steps []Step `json:"steps"`
+	// Dealer is the dealer's final hand.
+	Dealer Hand `json:"dealer"`
+	// Results are the settlements of every hand.
+	Results []Result `json:"results"`
+}
+
+// RecordedPlayer is a player as they were at the start of a round.
+type RecordedPlayer struct {
+	// Name is the name of the player.
+	Name string `json:"name"`
+	// Winnings are the winnings of the player before the round.
+	Winnings int `json:"winnings"`
+}
+
+// RecordedHand is a hand as it was at the start of a round.
+type RecordedHand struct {
+	// Player is the index of the hand's owner within Round.Players.
+	Player int `json:"player"`
+	// Wager is the wager placed on the hand.
+	Wager int `json:"wager"`
+	// Extra are the wagers after Wager of a Multi-Action hand.
+	Extra []int `json:"extra,omitempty"`
+	// Seat is the seat the hand is played from, zero at a table without seats.
+	Seat int `json:"seat,omitempty"`
+	// Second is whether the hand is the second hand of a seat in Blackjack Switch.
+	Second bool `json:"second,omitempty"`
+	// BackBets are the wagers placed on the hand by other players.
+	BackBets []BackBetSnapshot `json:"backBets,omitempty"`
+	// SideBets are the wagers on side bets placed on the hand for the round.
+	SideBets []RecordedSideBet `json:"sideBets,omitempty"`
+}
+
+// RecordedSideBet is a wager on a side bet as it was placed before a round. The side bet
+// is replayed by its name from the registered side bets.
+type RecordedSideBet struct {
+	// Name is the name of the side bet.
+	Name string `json:"name"`
+	// Wager is the amount wagered.
+	Wager int `json:"wager"`
+	// Jackpot is the state of the jackpot of a Progressive wager when the round started.
+	Jackpot *JackpotState `json:"jackpot,omitempty"`
+}
+
+// bet returns the side bet the wager was placed on. A Progressive wager is played against
+// a copy of its jackpot as it was recorded, which is shared through the jackpots by name.
+func (w RecordedSideBet) bet(jackpots map[string]*Jackpot) (SideBet, error) {
+	bet, ok := LookupSideBet(w.Name)
+	if w.Jackpot == nil {
+		if !ok {
+			return nil, fmt.Errorf("unknown side bet %q", w.Name)
+		}
+		return bet, nil
+	}
+	progressive, _ := bet.(Progressive)
+	jackpot, ok := jackpots[w.Name]
+	if !ok {
+		var err error
+		if jackpot, err = RestoreJackpot(*w.Jackpot); err != nil {
+			return nil, err
+		}
+		jackpots[w.Name] = jackpot
+	}
+	progressive.Jackpot = jackpot
+	return progressive, nil
+}
+
+// Step is a single action taken on a hand.
+type Step struct {
+	// Hand is the position of the hand within Game.Players when the action was taken.
+	Hand int `json:"hand"`
+	// Action is the action that was taken.
+	Action Action `json:"action"`
+}
+
+// Result is the settlement of a hand.
+type Result struct {
+	// Hand is the position of the hand within Game.Players when it was settled.
+	Hand int `json:"hand"`
+	// State is the WinState of the hand.
+	State WinState `json:"state"`
+	// Payout is the change to the player's winnings.
+	Payout int `json:"payout"`
+	// Bet is the position of the settled bet among the bets of a Multi-Action hand, zero
+	// for the hand's wager.
+	Bet int `json:"bet,omitempty"`
+}
+
+// ReadHistory decodes a history written in JSON, returning an error if the history was
+// written in an unsupported version. Histories of earlier versions are read without the
+// parts they did not record.
+func ReadHistory(r io.Reader) (*History, error) {
+	h := new(History)
+	if err := json.NewDecoder(r).Decode(h); err != nil {
+		return nil, err
+	}
+	if h.Version < 1 || h.Version > HistoryVersion {
+		return nil, fmt.Errorf("blackjack: unsupported history version %d", h.Version)
+	}
+	return h, nil
+}
+
+// WriteTo encodes the history as JSON.
+func (h *History) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// Replay returns a new Game in the state the round was in after the specified number
+// of steps. Replaying every step of a settled round also plays the dealer's hand and
+// collects the wagers.
+func (r *Round) Replay(step int) (*Game, error) {
+	if step < 0 || step > len(r.Steps) {
+		return nil, fmt.Errorf("blackjack: step %d is out of range", step)
+	}
+
+	game := New()
+	game.Rules = r.Rules
+	players := make([]*Player, 0, len(r.Players))
+	for _, p := range r.Players {
+		players = append(players, &Player{Name: p.Name, Winnings: p.Winnings})
+	}
+	// progressive wagers share a copy of their jackpot, so that replaying them leaves the
+	// jackpot they were played against untouched
+	jackpots := make(map[string]*Jackpot)
+	for i, hand := range r.Hands {
+		if hand.Player < 0 || hand.Player >= len(players) {
+			return nil, fmt.Errorf("blackjack: hand refers to unknown player %d", hand.Player)
+		}
+		val := &ListVal{
+			Player: players[hand.Player],
+			Wager:  hand.Wager,
+			Extra:  append([]int(nil), hand.Extra...),
+			Seat:   hand.Seat,
+			Second: hand.Second,
+		}
+		for _, bet := range hand.BackBets {
+			if bet.Player < 0 || bet.Player >= len(players) {
+				return nil, fmt.Errorf("blackjack: back bet on hand %d refers to unknown player %d", i, bet.Player)
+			}
+			val.BackBets = append(val.BackBets, &BackBet{
+				Player:       players[bet.Player],
+				Wager:        bet.Wager,
+				FollowDouble: bet.FollowDouble,
+				FollowSplit:  bet.FollowSplit,
+			})
+		}
+		for _, w := range hand.SideBets {
+			bet, err := w.bet(jackpots)
+			if err != nil {
+				return nil, fmt.Errorf("blackjack: side bet on hand %d: %w", i, err)
+			}
+			// the wager is placed without telling the side bet, which was told when the
+			// round was played
+			val.SideBets = append(val.SideBets, &SideWager{Bet: bet, Wager: w.Wager})
+		}
+		game.add(val)
+	}
+
+	dealer := game.Dealer
+	dealer.Stack(append(cards.Deck{}, r.Shoe...))
+	dealer.Deal(r.Cards, game.Players)
+	game.Start()
+
+	for i, s := range r.Steps[:step] {
+		if current := game.current(); current == nil || game.position(current) != s.Hand {
+			return nil, fmt.Errorf("blackjack: step %d was taken on hand %d out of turn", i, s.Hand)
+		}
+		if !dealer.Act(s.Action) {
+			return nil, fmt.Errorf("blackjack: step %d could not %s", i, s.Action)
+		}
+		dealer.Evaluate()
+	}
+
+	if step == len(r.Steps) && len(r.Results) > 0 {
+		dealer.Play()
+		dealer.Collect()
+	}
+	return game, nil
+}
+
+// Recorder listens to a game and records every round into a History.
+type Recorder struct {
+	game    *Game
+	seed    int64
+	history History
+}
+
+// NewRecorder returns a Recorder that records every round played in the specified game.
+func NewRecorder(g *Game) *Recorder {
+	r := &Recorder{
+		game:    g,
+		history: History{Version: HistoryVersion},
+	}
+	g.Listen(r.record)
+	return r
+}
+
+// History returns the rounds recorded so far.
+func (r *Recorder) History() *History {
+	return &r.history
+}
+
+func (r *Recorder) record(e Event) {
+	rounds := r.history.Rounds
+	switch e := e.(type) {
+	case ShoeReshuffled:
+		r.seed = e.Seed
+	case RoundStarted:
+		round := &Round{
+			Rules: r.game.Rules,
+			Seed:  r.seed,
+			Shoe:  r.game.Dealer.Shoe(),
+			Cards: e.Cards,
+		}
+		seats := make(map[*Player]int)
+		add := func(p *Player) {
+			if _, ok := seats[p]; !ok {
+				seats[p] = len(round.Players)
+				round.Players = append(round.Players, RecordedPlayer{Name: p.Name, Winnings: p.Winnings})
+			}
+		}
+		for _, val := range r.game.Hands().All() {
+			add(val.Player)
+			hand := RecordedHand{
+				Player: seats[val.Player],
+				Wager:  val.Wager,
+				Extra:  append([]int(nil), val.Extra...),
+				Seat:   val.Seat,
+				Second: val.Second,
+			}
+			for _, bet := range val.BackBets {
+				add(bet.Player)
+				hand.BackBets = append(hand.BackBets, BackBetSnapshot{
+					Player:       seats[bet.Player],
+					Wager:        bet.Wager,
+					FollowDouble: bet.FollowDouble,
+					FollowSplit:  bet.FollowSplit,
+				})
+			}
+			for _, w := range val.SideBets {
+				if w.Settled {
+					continue
+				}
+				bet := RecordedSideBet{Name: w.Bet.Name(), Wager: w.Wager}
+				if p, ok := w.Bet.(Progressive); ok && p.Jackpot != nil {
+					state := p.Jackpot.State()
+					bet.Jackpot = &state
+				}
+				hand.SideBets = append(hand.SideBets, bet)
+			}
+			round.Hands = append(round.Hands, hand)
+		}
+		r.history.Rounds = append(rounds, round)
+	case ActionTaken:
+		if len(rounds) == 0 {
+			return
+		}
+		round := rounds[len(rounds)-1]
+		round.Steps = append(round.Steps, Step{Hand: r.game.position(e.Hand), Action: e.Action})
+	case HandSettled:
+		if len(rounds) == 0 {
+			return
+		}
+		round := rounds[len(rounds)-1]
+		round.Dealer = append(Hand{}, r.game.Dealer.hand...)
+		round.Results = append(round.Results, Result{
+			Hand:   r.game.position(e.Hand),
+			State:  e.State,
+			Payout: e.Payout,
+			Bet:    e.Bet,
+		})
+	}
+}
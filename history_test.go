@@ -0,0 +1,220 @@
+package blackjack
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestRecorderReplay(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	game.AddPlayer(b)
+	recorder := NewRecorder(game)
+
+	dealer := game.Dealer
+	dealer.UseDecks(1)
+	dealer.Shuffle(7)
+	dealer.Stack(cards.Deck{
+		{Rank: cards.Eight, Suit: cards.Spades},
+		{Rank: cards.Ten, Suit: cards.Clubs},
+		{Rank: cards.Ten, Suit: cards.Hearts},
+		{Rank: cards.Eight, Suit: cards.Clubs},
+		{Rank: cards.Nine, Suit: cards.Diamonds},
+		{Rank: cards.Seven, Suit: cards.Hearts},
+		{Rank: cards.Three, Suit: cards.Spades},
+		{Rank: cards.King, Suit: cards.Diamonds},
+	})
+
+	dealer.Bet(game.Players.Head, 2)
+	dealer.Bet(game.Players.Tail.Head, 3)
+	dealer.Deal(2, game.Players)
+	game.Start()
+	dealer.Split()
+	dealer.Stay()
+	dealer.Stay()
+	dealer.Stay()
+	dealer.Play()
+	dealer.Collect()
+
+	var buf bytes.Buffer
+	if _, err := recorder.History().WriteTo(&buf); err != nil {
+		t.Fatalf("could not write history: %v", err)
+	}
+	history, err := ReadHistory(&buf)
+	if err != nil {
+		t.Fatalf("could not read history: %v", err)
+	}
+
+	if len(history.Rounds) != 1 {
+		t.Fatalf("expected one round to be recorded but got %d", len(history.Rounds))
+	}
+	round := history.Rounds[0]
+	if round.Seed != 7 || len(round.Steps) != 4 || len(round.Results) != 3 {
+		t.Fatalf("expected a round with seed 7, 4 steps and 3 results but got %d, %d and %d", round.Seed, len(round.Steps), len(round.Results))
+	}
+	if round.Steps[0].Action != Split {
+		t.Fatalf("expected the first step to be a split but was %s", round.Steps[0].Action)
+	}
+
+	replayed, err := round.Replay(1)
+	if err != nil {
+		t.Fatalf("could not replay the round: %v", err)
+	}
+	if replayed.Players.Len() != 3 {
+		t.Fatalf("expected the replayed game to have 3 hands after the split but has %d", replayed.Players.Len())
+	}
+	if replayed.position(replayed.Current.Head) != 0 {
+		t.Fatalf("expected the replay to wait on the first split hand")
+	}
+
+	replayed, err = round.Replay(len(round.Steps))
+	if err != nil {
+		t.Fatalf("could not replay the round: %v", err)
+	}
+	i := 0
+	for curr := replayed.Players; curr != nil; curr = curr.Tail {
+		original := game.Players
+		for j := 0; j < i; j++ {
+			original = original.Tail
+		}
+		if curr.Head.Hand.Value() != original.Head.Hand.Value() {
+			t.Fatalf("expected replayed hand %d to have a value of %d but has %d", i, original.Head.Hand.Value(), curr.Head.Hand.Value())
+		}
+		i++
+	}
+	if replayed.Players.Head.Player.Winnings != a.Winnings || replayed.Players.Tail.Tail.Head.Player.Winnings != b.Winnings {
+		t.Fatalf("expected replayed winnings to match the original game")
+	}
+	if replayed.Dealer.hand.Value() != round.Dealer.Value() {
+		t.Fatalf("expected the replayed dealer to have a value of %d but has %d", round.Dealer.Value(), replayed.Dealer.hand.Value())
+	}
+
+	if _, err := round.Replay(5); err == nil {
+		t.Fatalf("expected replaying past the last step to fail")
+	}
+}
+
+func TestReadHistoryVersion(t *testing.T) {
+	if _, err := ReadHistory(strings.NewReader(`{"version": 99, "rounds": []}`)); err == nil {
+		t.Fatalf("expected an unsupported history version to fail")
+	}
+}
+
+func TestRecorderReplaySideBets(t *testing.T) {
+	game := New()
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	recorder := NewRecorder(game)
+
+	dealer := game.Dealer
+	dealer.Stack(cards.Deck{
+		{Rank: cards.Eight, Suit: cards.Spades},
+		{Rank: cards.Ten, Suit: cards.Clubs},
+		{Rank: cards.Eight, Suit: cards.Spades},
+		{Rank: cards.Nine, Suit: cards.Clubs},
+	})
+	dealer.Bet(game.Players.Head, 10)
+	dealer.SideBet(game.Players.Head, PerfectPairs{}, 5)
+	dealer.Deal(2, game.Players)
+	game.Start()
+	dealer.Stay()
+	dealer.Play()
+	dealer.Collect()
+
+	// the hand loses 10 and the perfect pair pays 125
+	if a.Winnings != 115 {
+		t.Fatalf("expected winnings of 115 but got %d", a.Winnings)
+	}
+	round := recorder.History().Rounds[0]
+	if len(round.Hands[0].SideBets) != 1 || round.Hands[0].SideBets[0].Name != "Perfect Pairs" {
+		t.Fatalf("expected the side bet to be recorded with the hand")
+	}
+	replayed, err := round.Replay(len(round.Steps))
+	if err != nil {
+		t.Fatalf("could not replay the round: %v", err)
+	}
+	if replayed.Players.Head.Player.Winnings != a.Winnings {
+		t.Fatalf("expected replayed winnings of %d but got %d", a.Winnings, replayed.Players.Head.Player.Winnings)
+	}
+}
+
+func TestRecorderReplayBackBets(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	recorder := NewRecorder(game)
+
+	dealer := game.Dealer
+	dealer.Stack(cards.Deck{
+		{Rank: cards.Ten, Suit: cards.Spades},
+		{Rank: cards.Ten, Suit: cards.Clubs},
+		{Rank: cards.Nine, Suit: cards.Spades},
+		{Rank: cards.Seven, Suit: cards.Clubs},
+	})
+	dealer.Bet(game.Players.Head, 10)
+	dealer.BackBet(game.Players.Head, &BackBet{Player: b, Wager: 5})
+	dealer.Deal(2, game.Players)
+	game.Start()
+	dealer.Stay()
+	dealer.Play()
+	dealer.Collect()
+	if b.Winnings != 5 {
+		t.Fatalf("expected the backer to win 5 but got %d", b.Winnings)
+	}
+
+	round := recorder.History().Rounds[0]
+	if len(round.Players) != 2 || round.Players[1].Name != "b" {
+		t.Fatalf("expected the backer to be recorded as a player")
+	}
+	replayed, err := round.Replay(len(round.Steps))
+	if err != nil {
+		t.Fatalf("could not replay the round: %v", err)
+	}
+	bets := replayed.Hands().All()[0].BackBets
+	if len(bets) != 1 || bets[0].Player.Winnings != b.Winnings {
+		t.Fatalf("expected the replayed backer to win %d", b.Winnings)
+	}
+}
+
+func TestRecorderReplayProgressive(t *testing.T) {
+	jackpot := NewJackpot(1000, 10)
+	game := New()
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	recorder := NewRecorder(game)
+
+	ace := cards.Card{Rank: cards.Ace, Suit: cards.Spades}
+	dealer := game.Dealer
+	dealer.Stack(cards.Deck{
+		ace, ace, ace, ace,
+		{Rank: cards.Ten, Suit: cards.Clubs},
+		{Rank: cards.Nine, Suit: cards.Clubs},
+	})
+	dealer.Bet(game.Players.Head, 10)
+	dealer.SideBet(game.Players.Head, Progressive{Jackpot: jackpot, Wager: 10}, 10)
+	dealer.Deal(2, game.Players)
+	game.Start()
+	dealer.Stay()
+	dealer.Play()
+	dealer.Collect()
+	state := jackpot.State()
+	if state.Hits != 1 {
+		t.Fatalf("expected the jackpot to be hit")
+	}
+
+	round := recorder.History().Rounds[0]
+	replayed, err := round.Replay(len(round.Steps))
+	if err != nil {
+		t.Fatalf("could not replay the round: %v", err)
+	}
+	if replayed.Players.Head.Player.Winnings != a.Winnings {
+		t.Fatalf("expected replayed winnings of %d but got %d", a.Winnings, replayed.Players.Head.Player.Winnings)
+	}
+	if jackpot.State() != state {
+		t.Fatalf("expected the replay to leave the jackpot untouched")
+	}
+}
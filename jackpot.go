@@ -0,0 +1,211 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethanefung/cards"
+)
+
+// Jackpot is a progressive jackpot pool that grows by a percentage of every wager on it
+// and is shared by any number of tables. Every method of a Jackpot may be called from any
+// goroutine.
+type Jackpot struct {
+	mu        sync.Mutex
+	state     JackpotState
+	listeners []func(JackpotState)
+}
+
+// JackpotState is the serializable state of a Jackpot.
+type JackpotState struct {
+	// Amount is the value of the pool.
+	Amount int `json:"amount"`
+	// Seed is the value the pool is reset to once the jackpot is hit.
+	Seed int `json:"seed"`
+	// Rate is the percentage of every wager added to the pool.
+	Rate int `json:"rate"`
+	// Remainder is the hundredths of contributions that have yet to add up to a whole
+	// unit of the pool.
+	Remainder int `json:"remainder"`
+	// Hits is the number of times the jackpot has been hit.
+	Hits int `json:"hits"`
+}
+
+// NewJackpot returns a Jackpot that starts at and resets to the seed, and grows by the
+// rate percentage of every wager.
+func NewJackpot(seed, rate int) *Jackpot {
+	return &Jackpot{state: JackpotState{Amount: seed, Seed: seed, Rate: rate}}
+}
+
+// RestoreJackpot returns a Jackpot in the specified state.
+func RestoreJackpot(s JackpotState) (*Jackpot, error) {
+	if s.Amount < 0 || s.Seed < 0 {
+		return nil, fmt.Errorf("blackjack: jackpot must not be negative")
+	}
+	if s.Rate < 0 || s.Rate > 100 {
+		return nil, fmt.Errorf("blackjack: jackpot rate must be between 0 and 100 but was %d", s.Rate)
+	}
+	if s.Remainder < 0 || s.Remainder >= 100 {
+		return nil, fmt.Errorf("blackjack: jackpot remainder %d is out of range", s.Remainder)
+	}
+	return &Jackpot{state: s}, nil
+}
+
+// ReadJackpot decodes a jackpot written in JSON.
+func ReadJackpot(r io.Reader) (*Jackpot, error) {
+	var s JackpotState
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	return RestoreJackpot(s)
+}
+
+// WriteTo encodes the state of the jackpot as JSON.
+func (j *Jackpot) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.Marshal(j.State())
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// Listen registers the specified function to be called with the state of the jackpot
+// every time it changes, such as to persist it. The function is called while the
+// jackpot is locked, so it must not call the jackpot's methods.
+func (j *Jackpot) Listen(f func(JackpotState)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.listeners = append(j.listeners, f)
+}
+
+// State returns the state of the jackpot.
+func (j *Jackpot) State() JackpotState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state
+}
+
+// Amount returns the value of the pool.
+func (j *Jackpot) Amount() int {
+	return j.State().Amount
+}
+
+// Contribute adds the jackpot's rate of the wager to the pool.
+func (j *Jackpot) Contribute(wager int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	hundredths := wager*j.state.Rate + j.state.Remainder
+	j.state.Amount += hundredths / 100
+	j.state.Remainder = hundredths % 100
+	j.changed()
+}
+
+// Hit empties the pool, resetting it to the seed, and returns what it was worth.
+func (j *Jackpot) Hit() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	amount := j.state.Amount
+	j.state.Amount = j.state.Seed
+	j.state.Hits++
+	j.changed()
+	return amount
+}
+
+// changed calls the listeners of the jackpot. The lock of the jackpot must be held.
+func (j *Jackpot) changed() {
+	for _, f := range j.listeners {
+		f(j.state)
+	}
+}
+
+// The outcomes of the Progressive side bet.
+const (
+	FourSuitedAces = "Four Suited Aces"
+	FourAces       = "Four Aces"
+	ThreeAces      = "Three Aces"
+	SuitedAcePair  = "Suited Ace Pair"
+	AcePair        = "Ace Pair"
+)
+
+// ProgressivePays are the default amounts paid by Progressive for every outcome but
+// the jackpot.
+var ProgressivePays = map[string]int{FourAces: 500, ThreeAces: 100, SuitedAcePair: 50, AcePair: 10}
+
+// Progressive is a side bet on the aces among the hand's first two cards and the
+// dealer's first two cards. Four suited aces win the jackpot, while fewer aces win fixed
+// amounts regardless of the wager. A share of every wager goes into the jackpot, which
+// may be shared by many tables. It is settled at the end of the round so that the
+// dealer's hole card is known.
+//
+// Progressive is not registered by default, since its jackpot belongs to the caller.
+// Register it along with its jackpot to restore snapshots with progressive wagers.
+type Progressive struct {
+	// Jackpot is the pool won with four suited aces.
+	Jackpot *Jackpot
+	// Wager is the only wager the side bet takes, zero to take any wager.
+	Wager int
+	// Pays are the fixed amounts paid for every other outcome, ProgressivePays if nil.
+	Pays map[string]int
+}
+
+func (Progressive) Name() string          { return "Progressive" }
+func (Progressive) Timing() SideBetTiming { return AtRoundEnd }
+
+// Place adds the jackpot's share of the wager to the pool. Place returns false if the
+// side bet has no jackpot or takes a different wager.
+func (b Progressive) Place(wager int) bool {
+	if b.Jackpot == nil || (b.Wager != 0 && wager != b.Wager) {
+		return false
+	}
+	b.Jackpot.Contribute(wager)
+	return true
+}
+
+// Settle pays the jackpot for four suited aces, and fixed amounts for fewer aces.
+func (b Progressive) Settle(r SideBetRound, wager int) (string, int) {
+	var dealt []cards.Card
+	if len(r.Cards) >= 2 {
+		dealt = append(dealt, r.Cards[:2]...)
+	}
+	if len(r.Dealer) >= 2 {
+		dealt = append(dealt, r.Dealer[:2]...)
+	}
+	aces := 0
+	suits := make(map[cards.Suit]int)
+	for _, c := range dealt {
+		if c.Rank == cards.Ace {
+			aces++
+			suits[c.Suit]++
+		}
+	}
+	suitedPair := len(r.Cards) >= 2 && r.Cards[0].Rank == cards.Ace && r.Cards[0] == r.Cards[1]
+	pairs := len(r.Cards) >= 2 && r.Cards[0].Rank == cards.Ace && r.Cards[1].Rank == cards.Ace
+
+	var outcome string
+	switch {
+	case aces == 4 && len(suits) == 1:
+		if b.Jackpot == nil {
+			return FourSuitedAces, 0
+		}
+		return FourSuitedAces, b.Jackpot.Hit()
+	case aces == 4:
+		outcome = FourAces
+	case aces == 3:
+		outcome = ThreeAces
+	case suitedPair:
+		outcome = SuitedAcePair
+	case pairs:
+		outcome = AcePair
+	}
+	pays := b.Pays
+	if pays == nil {
+		pays = ProgressivePays
+	}
+	if amount, ok := pays[outcome]; ok && outcome != "" {
+		return outcome, amount
+	}
+	return "", -wager
+}
@@ -0,0 +1,108 @@
+package blackjack
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestJackpot(t *testing.T) {
+	jackpot := NewJackpot(1000, 15)
+	var saved []JackpotState
+	jackpot.Listen(func(s JackpotState) {
+		saved = append(saved, s)
+	})
+
+	jackpot.Contribute(5)
+	jackpot.Contribute(5)
+	if jackpot.Amount() != 1001 || jackpot.State().Remainder != 50 {
+		t.Fatalf("expected 15%% of two wagers of 5 to add 1.5 to the pool but was %+v", jackpot.State())
+	}
+	if len(saved) != 2 || saved[1].Amount != 1001 {
+		t.Fatalf("expected the listener to be told of every contribution")
+	}
+
+	var b bytes.Buffer
+	if _, err := jackpot.WriteTo(&b); err != nil {
+		t.Fatalf("expected the jackpot to be written but got %v", err)
+	}
+	restored, err := ReadJackpot(&b)
+	if err != nil || restored.State() != jackpot.State() {
+		t.Fatalf("expected the jackpot to be read back as it was written")
+	}
+
+	if jackpot.Hit() != 1001 || jackpot.Amount() != 1000 || jackpot.State().Hits != 1 {
+		t.Fatalf("expected hitting the jackpot to pay the pool and reset it to the seed")
+	}
+	if _, err := RestoreJackpot(JackpotState{Rate: 101}); err == nil {
+		t.Fatalf("expected a rate over 100%% to be refused")
+	}
+}
+
+func TestJackpotConcurrent(t *testing.T) {
+	jackpot := NewJackpot(0, 10)
+	tables := 8
+	rounds := 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < tables; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bet := Progressive{Jackpot: jackpot, Wager: 1}
+			for j := 0; j < rounds; j++ {
+				game := New()
+				game.AddPlayer(NewPlayer("a"))
+				game.Dealer.SideBet(game.Players.Head, bet, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if jackpot.Amount() != tables*rounds/10 {
+		t.Fatalf("expected every table's contributions to be added to the pool but was %d", jackpot.Amount())
+	}
+}
+
+func TestProgressive(t *testing.T) {
+	jackpot := NewJackpot(1000, 10)
+	bet := Progressive{Jackpot: jackpot, Wager: 1}
+
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	if game.Dealer.SideBet(game.Players.Head, bet, 5) {
+		t.Fatalf("expected a wager other than 1 to be refused")
+	}
+	if game.Dealer.SideBet(game.Players.Head, Progressive{}, 1) {
+		t.Fatalf("expected a progressive without a jackpot to be refused")
+	}
+
+	ace := func(s cards.Suit) cards.Card {
+		return cards.Card{Rank: cards.Ace, Suit: s}
+	}
+	ten := cards.Card{Rank: cards.Ten, Suit: cards.Clubs}
+	tests := []struct {
+		cards   Hand
+		dealer  Hand
+		outcome string
+		payout  int
+	}{
+		{Hand{ace(cards.Spades), ace(cards.Spades)}, Hand{ace(cards.Spades), ace(cards.Spades), ten}, FourSuitedAces, 1000},
+		{Hand{ace(cards.Spades), ace(cards.Spades)}, Hand{ace(cards.Spades), ace(cards.Hearts)}, FourAces, 500},
+		{Hand{ace(cards.Spades), ten}, Hand{ace(cards.Clubs), ace(cards.Hearts)}, ThreeAces, 100},
+		{Hand{ace(cards.Hearts), ace(cards.Hearts)}, Hand{ten, ten}, SuitedAcePair, 50},
+		{Hand{ace(cards.Hearts), ace(cards.Clubs)}, Hand{ten, ten}, AcePair, 10},
+		{Hand{ace(cards.Hearts), ten}, Hand{ten, ace(cards.Spades), ace(cards.Spades)}, "", -1},
+	}
+	for _, test := range tests {
+		outcome, payout := bet.Settle(SideBetRound{Cards: test.cards, Dealer: test.dealer}, 1)
+		if outcome != test.outcome || payout != test.payout {
+			t.Errorf("expected %v against %v to be %q paying %d but was %q paying %d", test.cards, test.dealer, test.outcome, test.payout, outcome, payout)
+		}
+	}
+	if jackpot.Amount() != 1000 || jackpot.State().Hits != 1 {
+		t.Fatalf("expected the jackpot to have been hit once and reset")
+	}
+}
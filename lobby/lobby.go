@@ -0,0 +1,349 @@
+/*
+Package lobby manages many blackjack tables that share their players.
+
+A player sits at one table at a time, and keeps their winnings as they move from table
+to table or step away from the tables altogether. Tables that have been abandoned by
+their players are torn down once they have been empty for long enough.
+
+Players must join, leave and move between tables through the Lobby so that it knows
+where everyone is seated. Bets and actions are made on the tables themselves.
+*/
+package lobby
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethanefung/blackjack"
+)
+
+var (
+	// ErrTableNotFound is returned when a table does not exist.
+	ErrTableNotFound = errors.New("lobby: table not found")
+	// ErrSeatedElsewhere is returned when a player joins a table while they are seated
+	// at another.
+	ErrSeatedElsewhere = errors.New("lobby: player is seated at another table")
+)
+
+// Lobby is a collection of tables and the players seated at them. Every method of a
+// Lobby may be called from any goroutine.
+type Lobby struct {
+	// Clock schedules the teardown of abandoned tables. Clock defaults to SystemClock.
+	Clock blackjack.Clock
+	// Idle is how long a table may go without players before it is torn down, zero to
+	// never tear down empty tables.
+	Idle time.Duration
+
+	mu      sync.Mutex
+	tables  map[string]*entry
+	players map[string]*member
+	nextID  int
+}
+
+// entry is a table of the lobby.
+type entry struct {
+	id    string
+	table *blackjack.Table
+	rules blackjack.Rules
+
+	mu     sync.Mutex
+	seated map[string]bool
+	gen    int
+	timer  blackjack.Timer
+}
+
+// member is a player known to the lobby.
+type member struct {
+	player *blackjack.Player
+	table  string
+}
+
+// TableInfo describes a table of the lobby.
+type TableInfo struct {
+	// ID is the identifier of the table.
+	ID string `json:"id"`
+	// Rules are the rules the table is played with.
+	Rules blackjack.Rules `json:"rules"`
+	// Players are the names of the players seated at the table.
+	Players []string `json:"players"`
+	// Playing is true while a round is being played.
+	Playing bool `json:"playing"`
+}
+
+// PlayerInfo describes a player of the lobby.
+type PlayerInfo struct {
+	// Name is the name of the player.
+	Name string `json:"name"`
+	// Table is the identifier of the table the player is seated at, empty if the player
+	// is not seated.
+	Table string `json:"table,omitempty"`
+	// Winnings are the winnings of the player.
+	Winnings int `json:"winnings"`
+}
+
+// New returns a Lobby without any tables.
+func New() *Lobby {
+	return &Lobby{
+		Clock:   blackjack.SystemClock,
+		tables:  make(map[string]*entry),
+		players: make(map[string]*member),
+	}
+}
+
+// Create adds a table played with the configuration to the lobby and returns its
+// identifier. Multi-Action is refused, since a table takes a single wager on every hand.
+func (l *Lobby) Create(c blackjack.TableConfig) (string, error) {
+	if err := c.Rules.Validate(); err != nil {
+		return "", err
+	}
+	if c.Rules.Variant == blackjack.MultiAction {
+		return "", fmt.Errorf("lobby: %s is not played at tables", c.Rules.Variant)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	e := &entry{
+		id:     strconv.Itoa(l.nextID),
+		table:  blackjack.NewTable(c),
+		rules:  c.Rules,
+		seated: make(map[string]bool),
+	}
+	e.table.Subscribe("", func(te blackjack.TableEvent) {
+		switch ev := te.Event.(type) {
+		case blackjack.PlayerSeated:
+			l.occupy(e, ev.Player, true)
+		case blackjack.PlayerLeft:
+			l.occupy(e, ev.Player, false)
+		}
+	})
+	l.occupy(e, "", false)
+	l.tables[e.id] = e
+	return e.id, nil
+}
+
+// Table returns the table with the specified identifier.
+func (l *Lobby) Table(id string) (*blackjack.Table, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.tables[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: table %s does not exist", ErrTableNotFound, id)
+	}
+	return e.table, nil
+}
+
+// Tables describes every table of the lobby in the order they were created.
+func (l *Lobby) Tables() []TableInfo {
+	l.mu.Lock()
+	entries := make([]*entry, 0, len(l.tables))
+	for _, e := range l.tables {
+		entries = append(entries, e)
+	}
+	l.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, _ := strconv.Atoi(entries[i].id)
+		b, _ := strconv.Atoi(entries[j].id)
+		return a < b
+	})
+	infos := make([]TableInfo, 0, len(entries))
+	for _, e := range entries {
+		infos = append(infos, TableInfo{
+			ID:      e.id,
+			Rules:   e.rules,
+			Players: e.table.Players(),
+			Playing: e.table.State("").Playing,
+		})
+	}
+	return infos
+}
+
+// Close tears down the table, unseating its players. A table may only be torn down
+// between rounds.
+func (l *Lobby) Close(id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.tables[id]
+	if !ok {
+		return fmt.Errorf("%w: table %s does not exist", ErrTableNotFound, id)
+	}
+	if err := e.table.Close(); err != nil {
+		return err
+	}
+	for _, name := range e.table.Players() {
+		if _, err := e.table.Leave(name); err != nil {
+			return err
+		}
+		l.players[name].table = ""
+	}
+	l.teardown(e)
+	return nil
+}
+
+// Join seats the named player at the lowest numbered empty seat of the table. A player
+// who has played in the lobby before keeps their winnings.
+func (l *Lobby) Join(id, name string) error {
+	return l.Sit(id, name, 0)
+}
+
+// Sit seats the named player at the numbered seat of the table, as with Table.Sit. A
+// player who has played in the lobby before keeps their winnings, and a player who is
+// already seated at the table takes another spot.
+func (l *Lobby) Sit(id, name string, seat int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.tables[id]
+	if !ok {
+		return fmt.Errorf("%w: table %s does not exist", ErrTableNotFound, id)
+	}
+	m, ok := l.players[name]
+	if !ok {
+		m = &member{player: blackjack.NewPlayer(name)}
+	}
+	if m.table != "" && m.table != id {
+		return fmt.Errorf("%w: %s is seated at table %s", ErrSeatedElsewhere, name, m.table)
+	}
+	if err := e.table.Sit(m.player, seat); err != nil {
+		return err
+	}
+	m.table = id
+	l.players[name] = m
+	return nil
+}
+
+// Leave unseats the named player from the table, keeping their winnings in the lobby.
+func (l *Lobby) Leave(id, name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.tables[id]
+	if !ok {
+		return fmt.Errorf("%w: table %s does not exist", ErrTableNotFound, id)
+	}
+	m, ok := l.players[name]
+	if !ok || m.table != id {
+		return fmt.Errorf("%w: %s is not seated", blackjack.ErrPlayerNotFound, name)
+	}
+	if _, err := e.table.Leave(name); err != nil {
+		return err
+	}
+	m.table = ""
+	return nil
+}
+
+// Move unseats the named player from their table and seats them at another, keeping
+// their winnings. The player stays at their table if they cannot be seated at the other.
+func (l *Lobby) Move(name, id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	to, ok := l.tables[id]
+	if !ok {
+		return fmt.Errorf("%w: table %s does not exist", ErrTableNotFound, id)
+	}
+	m, ok := l.players[name]
+	if !ok || m.table == "" {
+		return fmt.Errorf("%w: %s is not seated", blackjack.ErrPlayerNotFound, name)
+	}
+	if m.table == id {
+		return fmt.Errorf("%w: %s is already seated", blackjack.ErrAlreadySeated, name)
+	}
+	from := l.tables[m.table]
+	if to.table.State("").Playing {
+		return fmt.Errorf("%w: players may only join between rounds", blackjack.ErrRoundInProgress)
+	}
+	if _, err := from.table.Leave(name); err != nil {
+		return err
+	}
+	m.table = ""
+	if err := to.table.Seat(m.player); err != nil {
+		if from.table.Seat(m.player) == nil {
+			m.table = from.id
+		}
+		return err
+	}
+	m.table = id
+	return nil
+}
+
+// Player describes the named player.
+func (l *Lobby) Player(name string) (PlayerInfo, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m, ok := l.players[name]
+	if !ok {
+		return PlayerInfo{}, false
+	}
+	info := PlayerInfo{Name: name, Table: m.table}
+	if m.table == "" {
+		info.Winnings = m.player.Winnings
+		return info, true
+	}
+	// The winnings of a seated player change as the table plays, so they are read from
+	// the table.
+	for _, seat := range l.tables[m.table].table.State("").Seats {
+		if seat.Player == name {
+			info.Winnings = seat.Winnings
+		}
+	}
+	return info, true
+}
+
+// occupy tracks the players seated at the table, scheduling its teardown once it is
+// empty.
+func (l *Lobby) occupy(e *entry, name string, seated bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if seated {
+		e.seated[name] = true
+	} else {
+		delete(e.seated, name)
+	}
+	e.gen++
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+	if len(e.seated) == 0 && l.Idle > 0 {
+		gen := e.gen
+		e.timer = l.Clock.AfterFunc(l.Idle, func() {
+			l.reap(e, gen)
+		})
+	}
+}
+
+// reap tears down the table if it has stayed empty.
+func (l *Lobby) reap(e *entry, gen int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.mu.Lock()
+	abandoned := e.gen == gen && len(e.seated) == 0
+	e.mu.Unlock()
+	if abandoned && l.tables[e.id] == e {
+		l.teardown(e)
+	}
+}
+
+// teardown removes the table from the lobby. The lock of the lobby must be held.
+func (l *Lobby) teardown(e *entry) {
+	e.mu.Lock()
+	e.gen++
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+	e.mu.Unlock()
+
+	e.table.Close()
+	delete(l.tables, e.id)
+}
@@ -0,0 +1,115 @@
+package lobby
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethanefung/blackjack"
+)
+
+func TestLobbyTables(t *testing.T) {
+	l := New()
+	small, err := l.Create(blackjack.TableConfig{Rules: blackjack.Rules{Decks: 1, MaxBet: 10}})
+	if err != nil {
+		t.Fatalf("expected the table to be created but got %v", err)
+	}
+	big, _ := l.Create(blackjack.TableConfig{Rules: blackjack.Rules{Decks: 6, MinBet: 100}})
+	if _, err := l.Create(blackjack.TableConfig{Rules: blackjack.Rules{Decks: 12}}); err == nil {
+		t.Fatalf("expected invalid rules to be refused")
+	}
+	if _, err := l.Create(blackjack.TableConfig{Rules: blackjack.Rules{Variant: blackjack.MultiAction}}); err == nil {
+		t.Fatalf("expected a Multi-Action table to be refused")
+	}
+
+	l.Join(small, "a")
+	l.Join(small, "b")
+	l.Join(big, "c")
+	tables := l.Tables()
+	if len(tables) != 2 || tables[0].ID != small || tables[1].ID != big {
+		t.Fatalf("expected both tables to be listed in the order they were created")
+	}
+	if len(tables[0].Players) != 2 || len(tables[1].Players) != 1 || tables[1].Rules.MinBet != 100 {
+		t.Fatalf("expected the seats and rules of each table to be described")
+	}
+
+	if err := l.Join(big, "a"); !errors.Is(err, ErrSeatedElsewhere) {
+		t.Fatalf("expected a to be refused a second table but got %v", err)
+	}
+	if err := l.Join("9", "a"); !errors.Is(err, ErrTableNotFound) {
+		t.Fatalf("expected an unknown table to not be found but got %v", err)
+	}
+
+	if err := l.Close(small); err != nil {
+		t.Fatalf("expected the table to be torn down but got %v", err)
+	}
+	if info, _ := l.Player("a"); info.Table != "" {
+		t.Fatalf("expected a to be unseated once their table was torn down")
+	}
+	if _, err := l.Table(small); !errors.Is(err, ErrTableNotFound) {
+		t.Fatalf("expected a torn down table to not be found but got %v", err)
+	}
+}
+
+func TestLobbyMove(t *testing.T) {
+	l := New()
+	from, _ := l.Create(blackjack.TableConfig{Seed: func() int64 { return 1 }})
+	to, _ := l.Create(blackjack.TableConfig{})
+	l.Join(from, "a")
+
+	table, _ := l.Table(from)
+	table.Bet("a", 10)
+	if err := l.Move("a", to); !errors.Is(err, blackjack.ErrRoundInProgress) {
+		t.Fatalf("expected a to be refused a move during a round but got %v", err)
+	}
+	for table.State("").Playing {
+		table.Act("a", blackjack.Stay)
+	}
+	winnings := table.State("").Seats[0].Winnings
+	if winnings == 0 {
+		t.Fatalf("expected the seeded round to change a's winnings")
+	}
+
+	if err := l.Move("a", to); err != nil {
+		t.Fatalf("expected a to move between rounds but got %v", err)
+	}
+	info, _ := l.Player("a")
+	if info.Table != to || info.Winnings != winnings {
+		t.Fatalf("expected a to keep winnings of %d at table %s but had %d at %q", winnings, to, info.Winnings, info.Table)
+	}
+	if players := table.Players(); len(players) != 0 {
+		t.Fatalf("expected a to no longer be seated at the first table")
+	}
+
+	l.Leave(to, "a")
+	l.Join(from, "a")
+	if info, _ = l.Player("a"); info.Winnings != winnings {
+		t.Fatalf("expected a to keep their winnings when rejoining a table")
+	}
+}
+
+func TestLobbyReap(t *testing.T) {
+	clock := blackjack.NewFakeClock(time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC))
+	l := New()
+	l.Clock = clock
+	l.Idle = time.Minute
+
+	empty, _ := l.Create(blackjack.TableConfig{})
+	busy, _ := l.Create(blackjack.TableConfig{})
+	l.Join(busy, "a")
+
+	clock.Advance(30 * time.Second)
+	l.Join(empty, "b")
+	l.Leave(empty, "b")
+	clock.Advance(30 * time.Second)
+	if _, err := l.Table(empty); err != nil {
+		t.Fatalf("expected a table to not be reaped before it has been empty for long enough")
+	}
+	clock.Advance(30 * time.Second)
+	if _, err := l.Table(empty); !errors.Is(err, ErrTableNotFound) {
+		t.Fatalf("expected an abandoned table to be reaped but got %v", err)
+	}
+	if _, err := l.Table(busy); err != nil {
+		t.Fatalf("expected a table with players to not be reaped")
+	}
+}
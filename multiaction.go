@@ -0,0 +1,36 @@
+package blackjack
+
+// multiAction is the behaviour of MultiAction.
+type multiAction struct {
+	standard
+}
+
+// allows refuses to surrender, since a hand's bets are played against dealer's hands yet
+// to be drawn.
+func (s multiAction) allows(g *Game, val *ListVal, a Action) bool {
+	if a == Surrender {
+		return false
+	}
+	return s.standard.allows(g, val, a)
+}
+
+// MultiBet places two or three wagers on a hand of Multi-Action, the first as the hand's
+// Wager and the rest as its Extra wagers. MultiBet returns false if the game is not
+// Multi-Action, there are not two or three wagers or a wager is outside of the game's
+// betting limits.
+func (d *Dealer) MultiBet(listVal *ListVal, wagers ...int) bool {
+	if listVal == nil || d.Game.Rules.Variant != MultiAction || len(wagers) < 2 || len(wagers) > 3 {
+		return false
+	}
+	for _, wager := range wagers {
+		if !d.Game.Rules.AllowsBet(wager) {
+			return false
+		}
+	}
+	listVal.Wager = wagers[0]
+	listVal.Extra = append([]int(nil), wagers[1:]...)
+	for _, wager := range wagers {
+		d.Game.emit(BetPlaced{Hand: listVal, Wager: wager})
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestDealerMultiBet(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	val := game.Players.Head
+	if game.Dealer.MultiBet(val, 10, 10) {
+		t.Fatalf("expected the dealer to refuse several bets outside of Multi-Action")
+	}
+
+	game.Rules = Rules{Variant: MultiAction, MaxBet: 50}
+	if game.Dealer.MultiBet(val, 10) || game.Dealer.MultiBet(val, 10, 10, 10, 10) {
+		t.Fatalf("expected the dealer to refuse fewer than two or more than three bets")
+	}
+	if game.Dealer.MultiBet(val, 10, 100) {
+		t.Fatalf("expected the dealer to refuse a bet over the maximum")
+	}
+	if !game.Dealer.MultiBet(val, 10, 20) || val.Wager != 10 || len(val.Extra) != 1 || val.Extra[0] != 20 {
+		t.Fatalf("expected the first wager to be the hand's wager and the rest extra wagers")
+	}
+	if game.Dealer.Bet(val, 5); val.Extra != nil {
+		t.Fatalf("expected a single bet to remove the extra wagers")
+	}
+}
+
+func TestMultiAction(t *testing.T) {
+	game := New()
+	game.Rules.Variant = MultiAction
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.King}, {Rank: cards.Six}, {Rank: cards.Nine}, {Rank: cards.Ten},
+		{Rank: cards.Five},
+		{Rank: cards.Eight},
+		{Rank: cards.Six}, {Rank: cards.King},
+	})
+	val := game.Players.Head
+	game.Dealer.MultiBet(val, 10, 20, 30)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if game.Dealer.Surrender() {
+		t.Fatalf("expected the dealer to refuse a surrender in Multi-Action")
+	}
+	game.Dealer.Stay()
+	game.Dealer.Play()
+
+	hands := game.Dealer.Hands()
+	if len(hands) != 3 || hands[0].Value() != 21 || hands[1].Value() != 18 || hands[2].Value() != 26 {
+		t.Fatalf("expected the dealer to draw a hand for every bet but had %v", hands)
+	}
+	for _, h := range hands[1:] {
+		if h[1] != hands[0][1] {
+			t.Fatalf("expected the dealer to keep the upcard %v for every hand but had %v", hands[0][1], h)
+		}
+	}
+
+	bets := game.State().Hands[val.ID].Bets
+	if len(bets) != 3 || bets[0].Type != Lose || bets[1].Type != Win || bets[2].Type != Win {
+		t.Fatalf("expected each bet to be settled against its own dealer hand but was %+v", bets)
+	}
+
+	restored, err := Restore(game.Snapshot())
+	if err != nil || len(restored.Dealer.Hands()) != 3 || len(restored.Players.Head.Extra) != 2 {
+		t.Fatalf("expected the dealer's hands and the extra wagers to be restored but got %v", err)
+	}
+
+	game.Dealer.Collect()
+	if a.Winnings != 40 {
+		t.Fatalf("expected the bets to pay -10, 20 and 30 but winnings were %d", a.Winnings)
+	}
+	game.Dealer.Clear()
+	if len(game.Dealer.Hands()) != 1 {
+		t.Fatalf("expected the dealer's extra hands to be cleared")
+	}
+}
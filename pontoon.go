@@ -0,0 +1,82 @@
+package blackjack
+
+// pontoonNames are the names of the actions in Pontoon.
+var pontoonNames = map[Action]string{
+	Hit:    "Twist",
+	Stay:   "Stick",
+	Double: "Buy",
+	Split:  "Split",
+}
+
+// pontoon is the behaviour of Pontoon.
+type pontoon struct {
+	standard
+}
+
+// hidden deals both of the dealer's first two cards face down.
+func (pontoon) hidden(i int) bool {
+	return i < 2
+}
+
+// outcome ranks a pontoon over a five card trick over every other hand, and loses every
+// tie to the dealer.
+func (pontoon) outcome(val *ListVal, dealer Hand) WinType {
+	if dealer.Value() > 21 {
+		return Win
+	}
+	player, house := pontoonRank(val), pontoonRank(&ListVal{Hand: dealer})
+	if player == house {
+		player, house = val.Hand.Value(), dealer.Value()
+	}
+	if player > house {
+		return Win
+	}
+	return Lose
+}
+
+// pontoonRank returns 2 for a pontoon, 1 for a five card trick and 0 for any other hand.
+func pontoonRank(val *ListVal) int {
+	switch {
+	case val.natural():
+		return 2
+	case len(val.Hand) >= 5:
+		return 1
+	}
+	return 0
+}
+
+// allows refuses to stick below 15, and to twist or buy once the hand has five cards.
+// A hand may only buy once.
+func (s pontoon) allows(g *Game, val *ListVal, a Action) bool {
+	switch a {
+	case Stay:
+		return val.Hand.Value() >= 15
+	case Hit:
+		return len(val.Hand) < 5
+	case Double:
+		return len(val.Hand) < 5 && !val.Doubled
+	case Surrender, Switch:
+		return false
+	}
+	return s.standard.allows(g, val, a)
+}
+
+// rescues keeps a hand in play after buying a card, so that it may twist or stick.
+func (pontoon) rescues() bool {
+	return true
+}
+
+// done ends the turn of a hand that has bust or made a five card trick.
+func (s pontoon) done(val *ListVal) bool {
+	return s.standard.done(val) || len(val.Hand) >= 5
+}
+
+// charlies refuses the Charlie rule, since a five card trick already ranks below a
+// pontoon.
+func (pontoon) charlies() bool {
+	return false
+}
+
+func (pontoon) names() map[Action]string {
+	return pontoonNames
+}
@@ -0,0 +1,159 @@
+package blackjack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestPontoonActionNames(t *testing.T) {
+	for _, name := range []string{"twist", "Stick", "BUY", "split"} {
+		a, ok := Pontoon.ParseAction(name)
+		if !ok || !strings.EqualFold(Pontoon.ActionName(a), name) {
+			t.Fatalf("expected %s to be a Pontoon action but got %v", name, a)
+		}
+	}
+	if _, ok := Pontoon.ParseAction("hit"); ok {
+		t.Fatalf("expected hit to not be a Pontoon action")
+	}
+	if a, ok := Standard.ParseAction("hit"); !ok || a != Hit || Standard.ActionName(Hit) != "Hit" {
+		t.Fatalf("expected the standard actions to go by their own names")
+	}
+}
+
+func TestPontoonFiveCardTrick(t *testing.T) {
+	game := New()
+	game.Rules.Variant = Pontoon
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	faceDown := 0
+	game.Listen(func(e Event) {
+		if dealt, ok := e.(CardDealt); ok && !dealt.FaceUp {
+			faceDown++
+		}
+	})
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.King}, {Rank: cards.Three}, {Rank: cards.Seven},
+		{Rank: cards.Two}, {Rank: cards.Three}, {Rank: cards.Four},
+	})
+	game.Dealer.Bet(game.Players.Head, 10)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if faceDown != 2 || len(game.Dealer.ShowHand()) != 0 {
+		t.Fatalf("expected both of the dealer's cards to be dealt face down")
+	}
+	if game.Dealer.Stay() {
+		t.Fatalf("expected the dealer to refuse to stick below 15")
+	}
+	if game.Dealer.Suggest() != Hit {
+		t.Fatalf("expected a twist to be suggested without a dealer's card to go by")
+	}
+	for i := 0; i < 3; i++ {
+		game.Dealer.Hit()
+		game.Dealer.Evaluate()
+	}
+	if !game.PlayersPlayed() {
+		t.Fatalf("expected a five card trick to end the turn")
+	}
+	game.Dealer.Play()
+	game.Dealer.Collect()
+	if a.Winnings != 10 {
+		t.Fatalf("expected the five card trick to beat the dealer's 17 but winnings were %d", a.Winnings)
+	}
+}
+
+func TestPontoonCharlie(t *testing.T) {
+	game := New()
+	game.Rules = Rules{Variant: Pontoon, Charlie: 5}
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.Ace}, {Rank: cards.Three}, {Rank: cards.King},
+		{Rank: cards.Two}, {Rank: cards.Three}, {Rank: cards.Four},
+	})
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+	for i := 0; i < 3; i++ {
+		game.Dealer.Hit()
+		game.Dealer.Evaluate()
+	}
+	game.Dealer.Play()
+
+	if state := game.State().Hands[val.ID].State; state.Type != Lose {
+		t.Fatalf("expected the five card trick to lose to the dealer's pontoon but was %s", state.Type)
+	}
+	game.Dealer.Collect()
+	if a.Winnings != -10 {
+		t.Fatalf("expected the Charlie rule to be ignored in Pontoon but winnings were %d", a.Winnings)
+	}
+}
+
+func TestPontoonBuy(t *testing.T) {
+	game := New()
+	game.Rules.Variant = Pontoon
+	game.AddPlayer(NewPlayer("a"))
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.King}, {Rank: cards.Three}, {Rank: cards.Seven},
+		{Rank: cards.Two}, {Rank: cards.Three},
+	})
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if !game.Dealer.Double() || val.Wager != 20 || game.PlayersPlayed() {
+		t.Fatalf("expected buying a card to double the wager and keep the hand in play")
+	}
+	if game.Dealer.Double() {
+		t.Fatalf("expected the dealer to refuse a second buy")
+	}
+	if !game.Dealer.Hit() || len(val.Hand) != 4 {
+		t.Fatalf("expected the dealer to let the hand twist after buying")
+	}
+}
+
+func TestPontoonSideBets(t *testing.T) {
+	game := New()
+	game.Rules.Variant = Pontoon
+	game.AddPlayer(NewPlayer("a"))
+	val := game.Players.Head
+
+	if game.Dealer.SideBet(val, TwentyOnePlusThree{}, 5) {
+		t.Fatalf("expected a side bet on the dealer's face up card to be refused")
+	}
+	if !game.Dealer.SideBet(val, PerfectPairs{}, 5) {
+		t.Fatalf("expected a side bet on the hand's own cards to be placed")
+	}
+}
+
+func TestPontoonOutcomes(t *testing.T) {
+	card := func(r cards.Rank) cards.Card {
+		return cards.Card{Rank: r, Suit: cards.Spades}
+	}
+	pontoon := Hand{card(cards.Ace), card(cards.Jack)}
+	trick := Hand{card(cards.Two), card(cards.Three), card(cards.Two), card(cards.Four), card(cards.Five)}
+	tests := []struct {
+		hand    Hand
+		dealer  Hand
+		outcome WinType
+	}{
+		{Hand{card(cards.Ten), card(cards.Eight)}, Hand{card(cards.Jack), card(cards.Eight)}, Lose},
+		{Hand{card(cards.Ten), card(cards.Nine)}, Hand{card(cards.Jack), card(cards.Eight)}, Win},
+		{pontoon, pontoon, Lose},
+		{pontoon, trick, Win},
+		{trick, Hand{card(cards.Seven), card(cards.Seven), card(cards.Seven)}, Win},
+		{Hand{card(cards.Seven), card(cards.Seven), card(cards.Seven)}, pontoon, Lose},
+		{Hand{card(cards.Ten), card(cards.Five)}, Hand{card(cards.Ten), card(cards.Six), card(cards.Queen)}, Win},
+	}
+
+	v := Rules{Variant: Pontoon}.variant()
+	for _, test := range tests {
+		if outcome := v.outcome(&ListVal{Hand: test.hand}, test.dealer); outcome != test.outcome {
+			t.Errorf("expected %v against %v to %s but was %s", test.hand, test.dealer, test.outcome, outcome)
+		}
+	}
+}
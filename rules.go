@@ -0,0 +1,101 @@
+package blackjack
+
+import (
+	"fmt"
+)
+
+// Rules are the table rules a Game is played with. The zero value of Rules is a single
+// deck game without betting limits where the dealer hits a soft 17.
+type Rules struct {
+	// Decks is the number of decks in the shoe. Zero is treated as a single deck.
+	Decks int `json:"decks"`
+	// DealerStandsSoft17 is whether the dealer stands on a soft 17.
+	DealerStandsSoft17 bool `json:"dealerStandsSoft17"`
+	// MinBet is the smallest wager allowed on a hand, zero for no minimum.
+	MinBet int `json:"minBet"`
+	// MaxBet is the largest wager allowed on a hand, zero for no maximum.
+	MaxBet int `json:"maxBet"`
+	// Seats is the number of seats at the table, at most MaxSeats. Zero is a table
+	// without fixed seats where players are dealt in the order they are added.
+	Seats int `json:"seats"`
+	// Spots is the most seats a single player may play at once. Zero is a single seat.
+	Spots int `json:"spots"`
+	// Charlie is the number of cards, 5, 6 or 7, with which a hand that has not bust
+	// wins outright and ends the turn. Zero is a table without a Charlie rule.
+	Charlie int `json:"charlie,omitempty"`
+	// Variant is the game played at the table, Standard if unset.
+	Variant Variant `json:"variant,omitempty"`
+}
+
+// Validate returns an error if the rules cannot be played.
+func (r Rules) Validate() error {
+	if r.Decks < 0 || r.Decks > 8 {
+		return fmt.Errorf("blackjack: decks must be between 1 and 8 but was %d", r.Decks)
+	}
+	if r.MinBet < 0 || r.MaxBet < 0 {
+		return fmt.Errorf("blackjack: betting limits must not be negative")
+	}
+	if r.Seats < 0 || r.Seats > MaxSeats {
+		return fmt.Errorf("blackjack: seats must be between 1 and %d but was %d", MaxSeats, r.Seats)
+	}
+	if r.Spots < 0 || r.Spots > MaxSeats {
+		return fmt.Errorf("blackjack: spots must be between 1 and %d but was %d", MaxSeats, r.Spots)
+	}
+	if r.Charlie != 0 && (r.Charlie < 5 || r.Charlie > 7) {
+		return fmt.Errorf("blackjack: charlie must be 5, 6 or 7 cards but was %d", r.Charlie)
+	}
+	if r.Variant < 0 || int(r.Variant) >= len(_Variant_index)-1 {
+		return fmt.Errorf("blackjack: unknown variant %d", r.Variant)
+	}
+	if r.Charlie != 0 && !r.variant().charlies() {
+		return fmt.Errorf("blackjack: charlie is not played in %s", r.Variant)
+	}
+	// the shoe must hold the first two cards of every hand and the dealer's, and as many
+	// again to draw from
+	v := r.variant()
+	if hands := r.Seats*v.hands() + 1; r.Seats > 0 && len(v.deck())*r.ShoeSize() < 4*hands {
+		return fmt.Errorf("blackjack: a shoe of %d decks is too small for %d hands", r.ShoeSize(), hands-1)
+	}
+	if r.MaxBet > 0 && r.MinBet > r.MaxBet {
+		return fmt.Errorf("blackjack: minimum bet %d is over the maximum bet %d", r.MinBet, r.MaxBet)
+	}
+	return nil
+}
+
+// ShoeSize returns the number of decks in the shoe.
+func (r Rules) ShoeSize() int {
+	if r.Decks == 0 {
+		return 1
+	}
+	return r.Decks
+}
+
+// SpotLimit returns the most seats a single player may play at once.
+func (r Rules) SpotLimit() int {
+	if r.Spots == 0 {
+		return 1
+	}
+	return r.Spots
+}
+
+// AllowsBet returns true if the wager is within the table's betting limits.
+func (r Rules) AllowsBet(wager int) bool {
+	if wager < r.MinBet {
+		return false
+	}
+	return r.MaxBet == 0 || wager <= r.MaxBet
+}
+
+// charlie returns true if the hand wins outright by the Charlie rule. The rule does not
+// apply to variants that rank hands by their number of cards themselves.
+func (r Rules) charlie(h Hand) bool {
+	return r.Charlie > 0 && r.variant().charlies() && len(h) >= r.Charlie && h.Value() <= 21
+}
+
+// DealerHits returns true if the dealer must draw another card to the specified hand.
+func (r Rules) DealerHits(h Hand) bool {
+	if h.Value() < 17 {
+		return true
+	}
+	return h.Value() == 17 && h.HasAce() && !r.DealerStandsSoft17
+}
@@ -0,0 +1,74 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestRulesValidate(t *testing.T) {
+	if err := (Rules{}).Validate(); err != nil {
+		t.Fatalf("expected the zero value of rules to be valid but got %v", err)
+	}
+	if err := (Rules{Seats: 7, Decks: 2, Variant: BlackjackSwitch}).Validate(); err != nil {
+		t.Fatalf("expected two decks to cover seven seats of Blackjack Switch but got %v", err)
+	}
+	invalid := []Rules{
+		{Decks: 9},
+		{Decks: -1},
+		{MinBet: -1},
+		{MinBet: 10, MaxBet: 5},
+		{Seats: 8},
+		{Charlie: 4},
+		{Charlie: 8},
+		{Seats: 7, Variant: BlackjackSwitch},
+		{Charlie: 5, Variant: Pontoon},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Fatalf("expected rules %+v to be invalid", r)
+		}
+	}
+}
+
+func TestRulesBettingLimits(t *testing.T) {
+	game := New()
+	game.Rules = Rules{MinBet: 5, MaxBet: 100}
+	game.AddPlayer(NewPlayer("a"))
+
+	if game.Dealer.Bet(game.Players.Head, 2) {
+		t.Fatalf("expected a wager under the minimum bet to be refused")
+	}
+	if game.Dealer.Bet(game.Players.Head, 200) {
+		t.Fatalf("expected a wager over the maximum bet to be refused")
+	}
+	if !game.Dealer.Bet(game.Players.Head, 50) || game.Players.Head.Wager != 50 {
+		t.Fatalf("expected a wager within the betting limits to be placed")
+	}
+}
+
+func TestRulesDealerStandsSoft17(t *testing.T) {
+	game := New()
+	game.Rules.DealerStandsSoft17 = true
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Six},
+		{Rank: cards.Ace},
+		{Rank: cards.Ten},
+	})
+
+	game.Dealer.Play()
+
+	if game.Dealer.hand.Value() != 17 || len(game.Dealer.hand) != 2 {
+		t.Fatalf("expected the dealer to stand on soft 17 but drew to %d", game.Dealer.hand.Value())
+	}
+}
+
+func TestDealerUseRules(t *testing.T) {
+	game := New()
+	game.Rules.Decks = 6
+	game.Dealer.UseRules()
+
+	if game.Dealer.Remaining() != 6*52 {
+		t.Fatalf("expected the dealer to use a shoe of 6 decks but has %d cards", game.Dealer.Remaining())
+	}
+}
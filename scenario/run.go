@@ -0,0 +1,125 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethanefung/blackjack"
+)
+
+// Result is the outcome of running a scenario.
+type Result struct {
+	// Game is the game the scenario was played on.
+	Game *blackjack.Game
+	// State is the GameState of the game after the dealer has collected.
+	State blackjack.GameState
+	// Players are the players of the game in seat order.
+	Players []*blackjack.Player
+}
+
+// Run plays the scenario on a new game. The round is played to completion: the dealer
+// plays their hand once every seat has acted, and collects the wagers.
+func (s *Scenario) Run() (*Result, error) {
+	game := blackjack.New()
+	dealer := game.Dealer
+	players := make([]*blackjack.Player, 0, len(s.Seats))
+	for _, name := range s.Seats {
+		player := blackjack.NewPlayer(name)
+		players = append(players, player)
+		game.AddPlayer(player)
+	}
+	for _, val := range game.Hands().All() {
+		dealer.Bet(val, s.Bets[val.Player.Name])
+	}
+
+	dealer.Stack(s.Shoe)
+	if dealer.Remaining() < (game.Hands().Len()+1)*2 {
+		return nil, fmt.Errorf("shoe ran out of cards to deal the round")
+	}
+	dealer.Deal(2, game.Players)
+	game.Start()
+
+	for _, action := range s.Actions {
+		if game.PlayersPlayed() {
+			return nil, fmt.Errorf("line %d: %s cannot %s, every seat has already played", action.Line, action.Seat, action.Name)
+		}
+		current := game.Hands().Current().Player.Name
+		if current != action.Seat {
+			return nil, fmt.Errorf("line %d: %s cannot %s, it is %s's turn", action.Line, action.Seat, action.Name, current)
+		}
+		a, ok := blackjack.ParseAction(action.Name)
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown action %q", action.Line, action.Name)
+		}
+		if dealer.Remaining() < draws(a) {
+			return nil, fmt.Errorf("line %d: shoe ran out of cards for %s to %s", action.Line, action.Seat, action.Name)
+		}
+		if !dealer.Act(a) {
+			return nil, fmt.Errorf("line %d: %s is not allowed to %s", action.Line, action.Seat, action.Name)
+		}
+		dealer.Evaluate()
+	}
+	if !game.PlayersPlayed() {
+		return nil, fmt.Errorf("scenario ended while waiting on %s", game.Hands().Current().Player.Name)
+	}
+
+	// the dealer draws until they stand, which the shoe must hold enough cards for
+	hand, shoe := append(blackjack.Hand{}, dealer.ShowHand()...), dealer.Shoe()
+	for game.Rules.DealerHits(hand) {
+		if len(shoe) == 0 {
+			return nil, fmt.Errorf("shoe ran out of cards for the dealer to hit")
+		}
+		hand.Draw(shoe[0])
+		shoe = shoe[1:]
+	}
+
+	dealer.Play()
+	dealer.Collect()
+
+	return &Result{
+		Game:    game,
+		State:   game.State(),
+		Players: players,
+	}, nil
+}
+
+// String renders the dealer's hand, each seat's hand with its outcome, and the
+// winnings of every player. The output is stable so it can be compared to a golden
+// file.
+func (r *Result) String() string {
+	b := strings.Builder{}
+
+	dealerHand := r.Game.Dealer.ShowHand()
+	b.WriteString("dealer " + formatHand(dealerHand))
+	b.WriteString(fmt.Sprintf(" = %d %s\n", dealerHand.Value(), r.State.Dealer.Type))
+
+	for _, val := range r.Game.Hands().All() {
+		state := r.State.Hands[val.ID].State
+		b.WriteString("hand " + val.Player.Name + " " + formatHand(val.Hand))
+		b.WriteString(fmt.Sprintf(" = %d %s wager %d\n", state.Value, state.Type, val.Wager))
+	}
+
+	for _, player := range r.Players {
+		b.WriteString(fmt.Sprintf("winnings %s %d\n", player.Name, player.Winnings))
+	}
+	return b.String()
+}
+
+// draws returns the number of cards the action takes from the shoe.
+func draws(a blackjack.Action) int {
+	switch a {
+	case blackjack.Hit, blackjack.Double:
+		return 1
+	case blackjack.Split:
+		return 2
+	}
+	return 0
+}
+
+func formatHand(h blackjack.Hand) string {
+	s := make([]string, 0, len(h))
+	for _, card := range h {
+		s = append(s, FormatCard(card))
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,185 @@
+/*
+Package scenario describes whole rounds of blackjack as text and runs them against a
+blackjack.Game. A scenario lists the seats at the table, their bets, the stacked shoe
+and the actions taken, one directive per line:
+
+	# Player a splits aces and loses both hands to the dealer's 20.
+	seat a
+	bet a 2
+	shoe AS KH AH QC 8D 5C
+	act a split
+	act a stay
+	act a stay
+
+Cards are written as a rank (A, 2-9, T, J, Q, K) followed by a suit (S, H, D, C). The
+shoe is dealt in order, one card to every seat and then the dealer, twice. Actions are
+hit, stay, double, split and surrender, and must be taken by the seat whose turn it is.
+Blank lines and lines beginning with # are ignored.
+*/
+package scenario
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethanefung/cards"
+)
+
+// Scenario is a single round of blackjack.
+type Scenario struct {
+	// Seats are the names of the players at the table in the order they are dealt.
+	Seats []string
+	// Bets is the wager of each seat.
+	Bets map[string]int
+	// Shoe is the stacked deck the dealer deals from.
+	Shoe cards.Deck
+	// Actions are the moves made by the players in the order they are made.
+	Actions []Action
+}
+
+// Action is a single move made by a seat.
+type Action struct {
+	// Seat is the name of the player making the move.
+	Seat string
+	// Name is one of hit, stay, double, split or surrender.
+	Name string
+	// Line is the line of the scenario the action was read from.
+	Line int
+}
+
+var ranks = map[string]cards.Rank{
+	"A": cards.Ace,
+	"2": cards.Two,
+	"3": cards.Three,
+	"4": cards.Four,
+	"5": cards.Five,
+	"6": cards.Six,
+	"7": cards.Seven,
+	"8": cards.Eight,
+	"9": cards.Nine,
+	"T": cards.Ten,
+	"J": cards.Jack,
+	"Q": cards.Queen,
+	"K": cards.King,
+}
+
+var suits = map[string]cards.Suit{
+	"S": cards.Spades,
+	"H": cards.Hearts,
+	"D": cards.Diamonds,
+	"C": cards.Clubs,
+}
+
+// ParseCard returns the card written in the scenario notation, such as "AS" for the
+// ace of spades or "TD" for the ten of diamonds.
+func ParseCard(s string) (cards.Card, error) {
+	s = strings.ToUpper(s)
+	if strings.HasPrefix(s, "10") {
+		s = "T" + s[2:]
+	}
+	if len(s) != 2 {
+		return cards.Card{}, fmt.Errorf("invalid card %q", s)
+	}
+	rank, ok := ranks[s[:1]]
+	if !ok {
+		return cards.Card{}, fmt.Errorf("invalid rank in card %q", s)
+	}
+	suit, ok := suits[s[1:]]
+	if !ok {
+		return cards.Card{}, fmt.Errorf("invalid suit in card %q", s)
+	}
+	return cards.Card{Rank: rank, Suit: suit}, nil
+}
+
+// FormatCard returns the card written in the scenario notation.
+func FormatCard(c cards.Card) string {
+	var b strings.Builder
+	for s, rank := range ranks {
+		if rank == c.Rank {
+			b.WriteString(s)
+		}
+	}
+	for s, suit := range suits {
+		if suit == c.Suit {
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// ParseFile reads the scenario at the specified path.
+func ParseFile(path string) (*Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a scenario from r.
+func Parse(r io.Reader) (*Scenario, error) {
+	s := &Scenario{Bets: make(map[string]int)}
+	seated := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		directive, args := fields[0], fields[1:]
+		switch directive {
+		case "seat":
+			if len(args) != 1 {
+				return nil, fmt.Errorf("line %d: seat expects a name", line)
+			}
+			if seated[args[0]] {
+				return nil, fmt.Errorf("line %d: seat %q is already taken", line, args[0])
+			}
+			seated[args[0]] = true
+			s.Seats = append(s.Seats, args[0])
+		case "bet":
+			if len(args) != 2 {
+				return nil, fmt.Errorf("line %d: bet expects a seat and a wager", line)
+			}
+			if !seated[args[0]] {
+				return nil, fmt.Errorf("line %d: unknown seat %q", line, args[0])
+			}
+			wager, err := strconv.Atoi(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid wager %q", line, args[1])
+			}
+			s.Bets[args[0]] = wager
+		case "shoe":
+			for _, arg := range args {
+				card, err := ParseCard(arg)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				s.Shoe = append(s.Shoe, card)
+			}
+		case "act":
+			if len(args) != 2 {
+				return nil, fmt.Errorf("line %d: act expects a seat and an action", line)
+			}
+			if !seated[args[0]] {
+				return nil, fmt.Errorf("line %d: unknown seat %q", line, args[0])
+			}
+			s.Actions = append(s.Actions, Action{Seat: args[0], Name: args[1], Line: line})
+		default:
+			return nil, fmt.Errorf("line %d: unknown directive %q", line, directive)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.Seats) == 0 {
+		return nil, fmt.Errorf("scenario has no seats")
+	}
+	return s, nil
+}
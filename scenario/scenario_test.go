@@ -0,0 +1,104 @@
+package scenario
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the scenarios")
+
+func TestScenarios(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("expected scenarios within testdata but found none")
+	}
+
+	for _, path := range paths {
+		path := path
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		t.Run(name, func(t *testing.T) {
+			s, err := ParseFile(path)
+			if err != nil {
+				t.Fatalf("could not parse scenario: %v", err)
+			}
+			res, err := s.Run()
+			if err != nil {
+				t.Fatalf("could not run scenario: %v", err)
+			}
+
+			golden := strings.TrimSuffix(path, ".txt") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, []byte(res.String()), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("could not read golden file, run with -update to create it: %v", err)
+			}
+			if res.String() != string(want) {
+				t.Fatalf("scenario output does not match %s\nwant:\n%s\nhave:\n%s", golden, want, res.String())
+			}
+		})
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	card, err := ParseCard("10d")
+	if err != nil {
+		t.Fatalf("expected 10d to be parsed but got %v", err)
+	}
+	if card != (cards.Card{Rank: cards.Ten, Suit: cards.Diamonds}) {
+		t.Fatalf("expected 10d to be the ten of diamonds but was %v", card)
+	}
+	if FormatCard(card) != "TD" {
+		t.Fatalf("expected the ten of diamonds to be formatted as TD but was %s", FormatCard(card))
+	}
+
+	for _, s := range []string{"", "A", "1S", "AX", "ASS"} {
+		if _, err := ParseCard(s); err == nil {
+			t.Fatalf("expected %q to be an invalid card", s)
+		}
+	}
+}
+
+func TestScenarioErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown directive": "seat a\ndeal a",
+		"unknown seat":      "seat a\nbet b 2",
+		"no seats":          "shoe AS KS",
+		"bad wager":         "seat a\nbet a two",
+	}
+	for name, text := range tests {
+		if _, err := Parse(strings.NewReader(text)); err == nil {
+			t.Fatalf("%s: expected the scenario to be invalid", name)
+		}
+	}
+
+	runs := map[string]string{
+		"out of turn":   "seat a\nseat b\nshoe 2S 3S 4S 5S 6S 7S\nact b stay",
+		"not allowed":   "seat a\nshoe 2S 3S 4S 5S 6S 7S\nact a split",
+		"unfinished":    "seat a\nshoe 2S 3S 4S 5S",
+		"out of cards":  "seat a\nshoe 2S 3S 4S 5S\nact a hit",
+		"short deal":    "seat a\nshoe 2S 3S 4S",
+		"dealer short":  "seat a\nshoe TS 3S 9S 5S\nact a stay",
+		"round is over": "seat a\nshoe 2S 3S 4S 5S TS\nact a stay\nact a stay",
+	}
+	for name, text := range runs {
+		s, err := Parse(strings.NewReader(text))
+		if err != nil {
+			t.Fatalf("%s: could not parse scenario: %v", name, err)
+		}
+		if _, err := s.Run(); err == nil {
+			t.Fatalf("%s: expected the scenario to fail", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package blackjack
+
+// MaxSeats is the most seats a table may have.
+const MaxSeats = 7
+
+// Sit seats the player at the specified seat. Seats are numbered from 1 at first base,
+// which is dealt first, to Rules.Seats at third base. A list value is added for the
+// seat ahead of the seats after it, so that empty seats are skipped when dealing. A
+// player may sit at as many seats as Rules.SpotLimit allows, and plays a separate hand
+// from each. Sit returns false if the table has no such seat, the seat is taken, the
+// player has reached the limit or players are playing their hands.
+func (g *Game) Sit(p *Player, seat int) bool {
+	if seat < 1 || seat > g.Rules.Seats || g.current() != nil {
+		return false
+	}
+	if g.SeatedAt(seat) != nil || len(g.SeatsOf(p)) >= g.Rules.SpotLimit() {
+		return false
+	}
+	for len(g.seats) < g.Rules.Seats {
+		g.seats = append(g.seats, nil)
+	}
+	g.seats[seat-1] = p
+
+	hands := g.Hands()
+	i := 0
+	for i < hands.Len() && hands.At(i).Seat <= seat {
+		i++
+	}
+	return hands.Insert(i, &ListVal{Player: p, Seat: seat})
+}
+
+// Stand removes the player from all of their seats along with all of their list
+// values. Stand returns false if the player is not seated or players are playing their
+// hands.
+func (g *Game) Stand(p *Player) bool {
+	seats := g.SeatsOf(p)
+	if len(seats) == 0 || g.current() != nil {
+		return false
+	}
+	for _, seat := range seats {
+		g.seats[seat-1] = nil
+	}
+	g.RemovePlayer(p)
+	return true
+}
+
+// Vacate frees the specified seat along with the list values played from it, leaving
+// the player at any other seats they occupy. Vacate returns false if the seat is empty
+// or players are playing their hands.
+func (g *Game) Vacate(seat int) bool {
+	if g.SeatedAt(seat) == nil || g.current() != nil {
+		return false
+	}
+	g.seats[seat-1] = nil
+	for _, val := range g.Hands().All() {
+		if val.Seat == seat {
+			g.RemoveListVal(val)
+		}
+	}
+	return true
+}
+
+// SeatOf returns the lowest numbered seat of the player, or 0 if the player is not
+// seated.
+func (g *Game) SeatOf(p *Player) int {
+	if seats := g.SeatsOf(p); len(seats) > 0 {
+		return seats[0]
+	}
+	return 0
+}
+
+// SeatsOf returns every seat of the player from first base to third base.
+func (g *Game) SeatsOf(p *Player) []int {
+	var seats []int
+	for i, seated := range g.seats {
+		if seated != nil && seated == p {
+			seats = append(seats, i+1)
+		}
+	}
+	return seats
+}
+
+// SeatedAt returns the player at the specified seat, or nil if the seat is empty.
+func (g *Game) SeatedAt(seat int) *Player {
+	if seat < 1 || seat > len(g.seats) {
+		return nil
+	}
+	return g.seats[seat-1]
+}
+
+// EmptySeat returns the lowest numbered empty seat, or 0 if every seat is taken.
+func (g *Game) EmptySeat() int {
+	for seat := 1; seat <= g.Rules.Seats; seat++ {
+		if g.SeatedAt(seat) == nil {
+			return seat
+		}
+	}
+	return 0
+}
+
+// Seated returns the seated players in the order of their lowest numbered seat.
+func (g *Game) Seated() []*Player {
+	var players []*Player
+	seen := make(map[*Player]bool)
+	for _, p := range g.seats {
+		if p != nil && !seen[p] {
+			seen[p] = true
+			players = append(players, p)
+		}
+	}
+	return players
+}
@@ -0,0 +1,93 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestGameSit(t *testing.T) {
+	game := New()
+	game.Rules.Seats = 7
+	a, b, c := NewPlayer("a"), NewPlayer("b"), NewPlayer("c")
+
+	if !game.Sit(c, 7) || !game.Sit(a, 1) || !game.Sit(b, 4) {
+		t.Fatalf("expected a, b and c to be seated")
+	}
+	if game.Sit(NewPlayer("d"), 4) {
+		t.Fatalf("expected a taken seat to be refused")
+	}
+	if game.Sit(a, 2) {
+		t.Fatalf("expected a seated player to be refused a second seat")
+	}
+	if game.Sit(NewPlayer("d"), 8) || game.Sit(NewPlayer("d"), 0) {
+		t.Fatalf("expected a seat the table does not have to be refused")
+	}
+
+	order := []*Player{a, b, c}
+	i := 0
+	for curr := game.Players; curr != nil; curr = curr.Tail {
+		if curr.Head.Player != order[i] {
+			t.Fatalf("expected %s to be dealt in position %d but was %s", order[i].Name, i, curr.Head.Player.Name)
+		}
+		i++
+	}
+	if game.EmptySeat() != 2 || game.SeatOf(b) != 4 || game.SeatedAt(7) != c {
+		t.Fatalf("expected the seats of a, b and c to be found")
+	}
+
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two, Suit: cards.Clubs},
+		{Rank: cards.Three, Suit: cards.Clubs},
+		{Rank: cards.Four, Suit: cards.Clubs},
+		{Rank: cards.Five, Suit: cards.Clubs},
+	})
+	game.Dealer.Deal(1, game.Players)
+	if game.Players.Tail.Head.Hand[0].Rank != cards.Three {
+		t.Fatalf("expected the empty seats between a and b to be skipped when dealing")
+	}
+
+	game.Start()
+	if game.Sit(NewPlayer("d"), 2) || game.Stand(a) {
+		t.Fatalf("expected players to only sit and stand between rounds")
+	}
+	game.Current = nil
+	if !game.Stand(b) || game.SeatOf(b) != 0 || game.Players.Len() != 2 {
+		t.Fatalf("expected b to stand up with their hand")
+	}
+	if game.Stand(b) {
+		t.Fatalf("expected a player who is not seated to be refused")
+	}
+	if players := game.Seated(); len(players) != 2 || players[0] != a || players[1] != c {
+		t.Fatalf("expected a and c to remain seated in order")
+	}
+}
+
+func TestGameSitSpots(t *testing.T) {
+	game := New()
+	game.Rules = Rules{Seats: 5, Spots: 2}
+	a, b := NewPlayer("a"), NewPlayer("b")
+
+	if !game.Sit(a, 1) || !game.Sit(b, 2) || !game.Sit(a, 3) {
+		t.Fatalf("expected a to be seated at two seats")
+	}
+	if game.Sit(a, 4) {
+		t.Fatalf("expected a third seat for a to be refused")
+	}
+	if seats := game.SeatsOf(a); len(seats) != 2 || seats[0] != 1 || seats[1] != 3 {
+		t.Fatalf("expected a to be seated at seats 1 and 3 but was %v", seats)
+	}
+	if players := game.Seated(); len(players) != 2 {
+		t.Fatalf("expected two players to be seated but were %d", len(players))
+	}
+
+	if !game.Vacate(1) || game.SeatOf(a) != 3 {
+		t.Fatalf("expected a to keep seat 3 after vacating seat 1")
+	}
+	if game.Players.Len() != 2 || game.Players.Head.Player != b || game.Players.Tail.Head.Seat != 3 {
+		t.Fatalf("expected only the hand played from seat 1 to be removed")
+	}
+	if game.Vacate(1) {
+		t.Fatalf("expected an empty seat to not be vacated")
+	}
+}
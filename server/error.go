@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethanefung/blackjack"
+	"github.com/ethanefung/blackjack/lobby"
+)
+
+// Error is the structured error returned by the API. It is encoded as
+//
+//	{"error": {"code": "not_your_turn", "message": "it is b's turn"}}
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int `json:"-"`
+	// Code is a stable, machine readable description of the error.
+	Code string `json:"code"`
+	// Message is a human readable description of the error.
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func errorf(status int, code string, format string, a ...interface{}) *Error {
+	return &Error{Status: status, Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// tableErrors are the responses to the errors of a blackjack.Table.
+var tableErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{blackjack.ErrInvalidPlayer, http.StatusBadRequest, "invalid_player"},
+	{blackjack.ErrAlreadySeated, http.StatusConflict, "already_seated"},
+	{blackjack.ErrPlayerNotFound, http.StatusNotFound, "player_not_found"},
+	{blackjack.ErrRoundInProgress, http.StatusConflict, "round_in_progress"},
+	{blackjack.ErrRoundNotStarted, http.StatusConflict, "round_not_started"},
+	{blackjack.ErrNotYourTurn, http.StatusConflict, "not_your_turn"},
+	{blackjack.ErrInvalidBet, http.StatusUnprocessableEntity, "invalid_bet"},
+	{blackjack.ErrIllegalAction, http.StatusUnprocessableEntity, "illegal_action"},
+	{blackjack.ErrTableClosed, http.StatusConflict, "table_closed"},
+	{blackjack.ErrInvalidSeat, http.StatusUnprocessableEntity, "invalid_seat"},
+	{blackjack.ErrSeatTaken, http.StatusConflict, "seat_taken"},
+	{blackjack.ErrTableFull, http.StatusConflict, "table_full"},
+	{blackjack.ErrSpotLimit, http.StatusConflict, "spot_limit"},
+	{lobby.ErrTableNotFound, http.StatusNotFound, "table_not_found"},
+	{lobby.ErrSeatedElsewhere, http.StatusConflict, "seated_elsewhere"},
+}
+
+// tableError converts an error of a blackjack.Table or a lobby.Lobby into an Error.
+func tableError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, e := range tableErrors {
+		if errors.Is(err, e.err) {
+			return errorf(e.status, e.code, "%s", strings.TrimPrefix(err.Error(), e.err.Error()+": "))
+		}
+	}
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*Error)
+	if !ok {
+		e = errorf(http.StatusInternalServerError, "internal", "%v", err)
+	}
+	writeJSON(w, e.Status, struct {
+		Error *Error `json:"error"`
+	}{e})
+}
@@ -0,0 +1,189 @@
+/*
+Package server hosts blackjack tables behind a JSON API.
+
+	POST   /tables                        create a table, the body is blackjack.Rules
+	GET    /tables                        list the tables
+	GET    /tables/{id}?player={name}     the state of a table as seen by the player
+	POST   /tables/{id}/players           join a table, the body is {"name": "a", "seat": 3}
+	DELETE /tables/{id}/players/{name}    leave a table
+	POST   /tables/{id}/bets              bet, the body is {"player": "a", "seat": 3, "wager": 10}
+	POST   /tables/{id}/actions           act, the body is {"player": "a", "action": "hit"}
+	GET    /tables/{id}/ws?player={name}  push the table's messages over a WebSocket
+
+A round is dealt once every seated player has bet, and the dealer plays and settles the
+round once every hand has been played. Players may only join and leave between rounds,
+and may only sit at one table at a time. A player who does not ask for a seat is seated
+at the lowest numbered empty seat. A player who joins a table they are seated at takes
+another spot if the rules allow it, and a bet without a seat is placed on every spot of
+the player.
+Errors are returned as {"error": {"code": "...", "message": "..."}}.
+
+Clients connected over a WebSocket receive a snapshot of the table followed by a
+Message for every event of the table, and may send a Request to bet or to act on behalf
+of the player named in the query. Actions are only accepted from the player whose turn
+it is.
+*/
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethanefung/blackjack"
+	"github.com/ethanefung/blackjack/lobby"
+)
+
+// Server is an http.Handler hosting many tables.
+type Server struct {
+	// Seed returns the seed used to shuffle a shoe. Seed defaults to the current time.
+	Seed func() int64
+	// Clock schedules the timeouts of the tables. Clock defaults to the system clock.
+	Clock blackjack.Clock
+	// Timeouts are the deadlines of the tables created by the server.
+	Timeouts blackjack.Timeouts
+	// Lobby holds the tables of the server and the players seated at them.
+	Lobby *lobby.Lobby
+}
+
+// New returns a Server without any tables.
+func New() *Server {
+	return &Server{
+		Seed:  func() int64 { return time.Now().UnixNano() },
+		Clock: blackjack.SystemClock,
+		Lobby: lobby.New(),
+	}
+}
+
+// ServeHTTP routes the request to the handler of the API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "tables" {
+		writeError(w, errorf(http.StatusNotFound, "not_found", "%s does not exist", r.URL.Path))
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listTables(w, r)
+		case http.MethodPost:
+			s.createTable(w, r)
+		default:
+			writeError(w, errorf(http.StatusMethodNotAllowed, "method_not_allowed", "%s is not allowed", r.Method))
+		}
+		return
+	}
+
+	t, err := s.table(parts[1])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	route := r.Method + " " + strings.Join(parts[2:], "/")
+	switch {
+	case route == "GET ":
+		writeJSON(w, http.StatusOK, t.state(r.URL.Query().Get("player")))
+	case route == "GET ws":
+		s.serveWS(w, r, t)
+	case route == "POST players":
+		var body struct {
+			Name string `json:"name"`
+			Seat int    `json:"seat"`
+		}
+		if decode(w, r, &body) {
+			respond(w, t, body.Name, tableError(s.Lobby.Sit(t.id, body.Name, body.Seat)))
+		}
+	case len(parts) == 4 && route == "DELETE players/"+parts[3]:
+		respond(w, t, parts[3], tableError(s.Lobby.Leave(t.id, parts[3])))
+	case route == "POST bets":
+		var body struct {
+			Player string `json:"player"`
+			Seat   int    `json:"seat"`
+			Wager  int    `json:"wager"`
+		}
+		if decode(w, r, &body) {
+			respond(w, t, body.Player, t.bet(body.Player, body.Seat, body.Wager))
+		}
+	case route == "POST actions":
+		var body struct {
+			Player string `json:"player"`
+			Action string `json:"action"`
+		}
+		if decode(w, r, &body) {
+			respond(w, t, body.Player, t.act(body.Player, body.Action))
+		}
+	default:
+		writeError(w, errorf(http.StatusNotFound, "not_found", "%s %s does not exist", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) table(id string) (*table, error) {
+	t, err := s.Lobby.Table(id)
+	if err != nil {
+		return nil, tableError(err)
+	}
+	return &table{Table: t, id: id}, nil
+}
+
+func (s *Server) createTable(w http.ResponseWriter, r *http.Request) {
+	var rules blackjack.Rules
+	if !decode(w, r, &rules) {
+		return
+	}
+	id, err := s.Lobby.Create(blackjack.TableConfig{
+		Rules:    rules,
+		Timeouts: s.Timeouts,
+		Clock:    s.Clock,
+		Seed:     s.Seed,
+	})
+	if err != nil {
+		writeError(w, errorf(http.StatusUnprocessableEntity, "invalid_rules", "%v", err))
+		return
+	}
+	t, err := s.table(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, t.state(""))
+}
+
+func (s *Server) listTables(w http.ResponseWriter, r *http.Request) {
+	infos := s.Lobby.Tables()
+	states := make([]TableState, 0, len(infos))
+	for _, info := range infos {
+		// A table may be torn down while the tables are listed.
+		if t, err := s.table(info.ID); err == nil {
+			states = append(states, t.state(""))
+		}
+	}
+	writeJSON(w, http.StatusOK, states)
+}
+
+// decode reads the JSON body of the request into v, writing an error response and
+// returning false if the body is invalid.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Body == nil {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, errorf(http.StatusBadRequest, "invalid_body", "%v", err))
+		return false
+	}
+	return true
+}
+
+// respond writes the error if there is one, otherwise the state of the table as seen by
+// the player.
+func respond(w http.ResponseWriter, t *table, player string, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t.state(player))
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type client struct {
+	t   *testing.T
+	url string
+}
+
+func (c client) do(method, path string, body interface{}, v interface{}) int {
+	c.t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req, err := http.NewRequest(method, c.url+path, &buf)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			c.t.Fatalf("could not decode response of %s %s: %v", method, path, err)
+		}
+	}
+	return res.StatusCode
+}
+
+type errorResponse struct {
+	Error Error `json:"error"`
+}
+
+func newTestServer(t *testing.T) (client, func()) {
+	s := New()
+	s.Seed = func() int64 { return 1 }
+	ts := httptest.NewServer(s)
+	return client{t: t, url: ts.URL}, ts.Close
+}
+
+func TestServerRound(t *testing.T) {
+	c, done := newTestServer(t)
+	defer done()
+
+	var table TableState
+	if status := c.do("POST", "/tables", map[string]interface{}{"decks": 2, "minBet": 1}, &table); status != http.StatusCreated {
+		t.Fatalf("expected the table to be created but got status %d", status)
+	}
+	path := "/tables/" + table.ID
+
+	for _, name := range []string{"a", "b"} {
+		if status := c.do("POST", path+"/players", map[string]string{"name": name}, nil); status != http.StatusOK {
+			t.Fatalf("expected %s to join the table but got status %d", name, status)
+		}
+	}
+
+	var e errorResponse
+	if status := c.do("POST", path+"/players", map[string]string{"name": "a"}, &e); status != http.StatusConflict || e.Error.Code != "already_seated" {
+		t.Fatalf("expected a second seat for a to be refused but got %d %q", status, e.Error.Code)
+	}
+
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, &table)
+	if table.Playing {
+		t.Fatalf("expected the table to wait on b's bet before dealing")
+	}
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "b", "wager": 10}, &table)
+	if !table.Playing || table.Turn != "a" {
+		t.Fatalf("expected the round to be dealt and wait on a once every player has bet")
+	}
+	if table.View.Dealer.Hidden != 1 {
+		t.Fatalf("expected the dealer's hole card to be hidden while the round is played")
+	}
+
+	if status := c.do("POST", path+"/players", map[string]string{"name": "c"}, &e); status != http.StatusConflict || e.Error.Code != "round_in_progress" {
+		t.Fatalf("expected joining during a round to be refused but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", path+"/actions", map[string]string{"player": "b", "action": "stay"}, &e); status != http.StatusConflict || e.Error.Code != "not_your_turn" {
+		t.Fatalf("expected b acting out of turn to be refused but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", path+"/actions", map[string]string{"player": "a", "action": "twist"}, &e); status != http.StatusBadRequest || e.Error.Code != "invalid_action" {
+		t.Fatalf("expected an unknown action to be refused but got %d %q", status, e.Error.Code)
+	}
+
+	c.do("POST", path+"/actions", map[string]string{"player": "a", "action": "stay"}, &table)
+	if table.Turn != "b" {
+		t.Fatalf("expected b's turn after a stays but it was %q's", table.Turn)
+	}
+	c.do("POST", path+"/actions", map[string]string{"player": "b", "action": "stay"}, &table)
+	if table.Playing {
+		t.Fatalf("expected the round to be settled once every player has played")
+	}
+	if len(table.Results) != 2 {
+		t.Fatalf("expected both hands to be settled but got %d results", len(table.Results))
+	}
+	if table.View.Dealer.Hidden != 0 {
+		t.Fatalf("expected the dealer's hand to be revealed once the round is settled")
+	}
+
+	if status := c.do("DELETE", path+"/players/b", nil, &table); status != http.StatusOK || len(table.Seats) != 1 {
+		t.Fatalf("expected b to leave the table between rounds")
+	}
+}
+
+func TestServerPontoon(t *testing.T) {
+	c, done := newTestServer(t)
+	defer done()
+
+	var table TableState
+	if status := c.do("POST", "/tables", map[string]interface{}{"variant": "Pontoon"}, &table); status != http.StatusCreated {
+		t.Fatalf("expected the Pontoon table to be created but got status %d", status)
+	}
+	path := "/tables/" + table.ID
+	c.do("POST", path+"/players", map[string]string{"name": "a"}, nil)
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, &table)
+	if table.View.Dealer.Hidden != 2 {
+		t.Fatalf("expected both of the dealer's cards to be hidden but %d were", table.View.Dealer.Hidden)
+	}
+
+	var e errorResponse
+	if status := c.do("POST", path+"/actions", map[string]string{"player": "a", "action": "hit"}, &e); status != http.StatusBadRequest || e.Error.Code != "invalid_action" {
+		t.Fatalf("expected hit to not be a Pontoon action but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", path+"/actions", map[string]string{"player": "a", "action": "twist"}, &table); status != http.StatusOK {
+		t.Fatalf("expected a to twist but got status %d", status)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	c, done := newTestServer(t)
+	defer done()
+
+	var e errorResponse
+	if status := c.do("GET", "/tables/7", nil, &e); status != http.StatusNotFound || e.Error.Code != "table_not_found" {
+		t.Fatalf("expected an unknown table to not be found but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", "/tables", map[string]int{"decks": 12}, &e); status != http.StatusUnprocessableEntity || e.Error.Code != "invalid_rules" {
+		t.Fatalf("expected invalid rules to be refused but got %d %q", status, e.Error.Code)
+	}
+
+	var table TableState
+	c.do("POST", "/tables", map[string]int{"minBet": 5}, &table)
+	path := "/tables/" + table.ID
+	c.do("POST", path+"/players", map[string]string{"name": "a"}, nil)
+
+	if status := c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 1}, &e); status != http.StatusUnprocessableEntity || e.Error.Code != "invalid_bet" {
+		t.Fatalf("expected a bet under the minimum to be refused but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", path+"/actions", map[string]string{"player": "a", "action": "hit"}, &e); status != http.StatusConflict || e.Error.Code != "round_not_started" {
+		t.Fatalf("expected an action before the round to be refused but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("DELETE", path+"/players/z", nil, &e); status != http.StatusNotFound || e.Error.Code != "player_not_found" {
+		t.Fatalf("expected an unknown player to not be found but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", path+"/players", map[string]interface{}{"name": "b", "seat": 2}, &e); status != http.StatusUnprocessableEntity || e.Error.Code != "invalid_seat" {
+		t.Fatalf("expected a seat at a table without seats to be refused but got %d %q", status, e.Error.Code)
+	}
+	if status := c.do("POST", path+"/bets", "{", &e); status != http.StatusBadRequest || e.Error.Code != "invalid_body" {
+		t.Fatalf("expected an invalid body to be refused but got %d %q", status, e.Error.Code)
+	}
+
+	var tables []TableState
+	if c.do("GET", "/tables", nil, &tables); len(tables) != 1 {
+		t.Fatalf("expected one table to be listed but got %d", len(tables))
+	}
+	if status := c.do("DELETE", path+"/players/a", nil, nil); status != http.StatusOK {
+		t.Fatalf("expected the only player to be able to leave the table but got %d", status)
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/ethanefung/blackjack"
+)
+
+// table is a single blackjack.Table hosted by the server.
+type table struct {
+	*blackjack.Table
+	id string
+}
+
+// TableState is the response describing a table.
+type TableState struct {
+	// ID is the identifier of the table.
+	ID string `json:"id"`
+	blackjack.TableState
+}
+
+// state returns the state of the table as seen by the named player.
+func (t *table) state(name string) TableState {
+	return TableState{ID: t.id, TableState: t.State(name)}
+}
+
+func (t *table) bet(name string, seat int, wager int) error {
+	return tableError(t.BetAt(name, seat, wager))
+}
+
+func (t *table) act(name string, action string) error {
+	a, ok := t.Rules().Variant.ParseAction(action)
+	if !ok {
+		return errorf(http.StatusBadRequest, "invalid_action", "%q is not an action", action)
+	}
+	return tableError(t.Act(name, a))
+}
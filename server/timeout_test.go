@@ -0,0 +1,103 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethanefung/blackjack"
+)
+
+func newTimeoutServer(t *testing.T, timeouts blackjack.Timeouts) (client, *blackjack.FakeClock, func()) {
+	clock := blackjack.NewFakeClock(time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC))
+	s := New()
+	s.Seed = func() int64 { return 1 }
+	s.Clock = clock
+	s.Timeouts = timeouts
+	ts := httptest.NewServer(s)
+	return client{t: t, url: ts.URL}, clock, ts.Close
+}
+
+func (c client) table(path string) TableState {
+	var table TableState
+	c.do("GET", path, nil, &table)
+	return table
+}
+
+func TestServerTurnTimeout(t *testing.T) {
+	c, clock, done := newTimeoutServer(t, blackjack.Timeouts{Turn: 30 * time.Second, SitOut: 2})
+	defer done()
+
+	var table TableState
+	c.do("POST", "/tables", map[string]int{"decks": 6}, &table)
+	path := "/tables/" + table.ID
+	c.do("POST", path+"/players", map[string]string{"name": "a"}, nil)
+	c.do("POST", path+"/players", map[string]string{"name": "b"}, nil)
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, nil)
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "b", "wager": 5}, nil)
+
+	clock.Advance(29 * time.Second)
+	if table = c.table(path); table.Turn != "a" {
+		t.Fatalf("expected a's turn to not time out early but it is %q's turn", table.Turn)
+	}
+	clock.Advance(time.Second)
+	if table = c.table(path); table.Turn != "b" {
+		t.Fatalf("expected a to stay once their turn timed out but it is %q's turn", table.Turn)
+	}
+	clock.Advance(30 * time.Second)
+	if table = c.table(path); table.Playing {
+		t.Fatalf("expected the round to be settled once b's turn timed out")
+	}
+
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, nil)
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "b", "wager": 5}, nil)
+	c.do("POST", path+"/actions", map[string]string{"player": "a", "action": "stay"}, nil)
+	clock.Advance(30 * time.Second)
+
+	table = c.table(path)
+	if table.Seats[0].SittingOut || !table.Seats[1].SittingOut {
+		t.Fatalf("expected only b to sit out after timing out twice in a row")
+	}
+
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, &table)
+	if !table.Playing || len(table.View.Hands) != 1 {
+		t.Fatalf("expected the round to be dealt to a alone while b sits out")
+	}
+}
+
+func TestServerBettingTimeout(t *testing.T) {
+	c, clock, done := newTimeoutServer(t, blackjack.Timeouts{Betting: 10 * time.Second})
+	defer done()
+
+	var table TableState
+	c.do("POST", "/tables", map[string]int{"decks": 6}, &table)
+	path := "/tables/" + table.ID
+	c.do("POST", path+"/players", map[string]string{"name": "a"}, nil)
+	c.do("POST", path+"/players", map[string]string{"name": "b"}, nil)
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, nil)
+
+	clock.Advance(10 * time.Second)
+	table = c.table(path + "?player=a")
+	if !table.Playing || len(table.View.Hands) != 1 || !table.View.Hands[0].Mine {
+		t.Fatalf("expected the round to be dealt to a alone once betting timed out")
+	}
+}
+
+func TestServerBasicStrategyTimeout(t *testing.T) {
+	c, clock, done := newTimeoutServer(t, blackjack.Timeouts{Turn: time.Second, Auto: blackjack.AutoBasicStrategy})
+	defer done()
+
+	var table TableState
+	c.do("POST", "/tables", map[string]int{"decks": 6}, &table)
+	path := "/tables/" + table.ID
+	c.do("POST", path+"/players", map[string]string{"name": "a"}, nil)
+	c.do("POST", path+"/bets", map[string]interface{}{"player": "a", "wager": 5}, &table)
+
+	for i := 0; i < 10 && table.Playing; i++ {
+		clock.Advance(time.Second)
+		table = c.table(path)
+	}
+	if table.Playing {
+		t.Fatalf("expected the round to be played out by basic strategy")
+	}
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethanefung/blackjack"
+	"github.com/ethanefung/cards"
+	"github.com/gorilla/websocket"
+)
+
+// Message is pushed to the clients connected to a table over a WebSocket. Every event has
+// a sequence number higher than the one before it, while an error repeats the sequence
+// number of the message before it. The first message of every
+// connection is a snapshot of the table, so a client that reconnects resyncs from the
+// snapshot and ignores any message with a lower sequence number.
+type Message struct {
+	// Seq is the sequence number of the message within the table.
+	Seq int `json:"seq"`
+	// Type is "snapshot", "turn", "seated", "left", "timeout", "sitout", "error" or the
+	// name of a blackjack.Event.
+	Type string `json:"type"`
+	// Player is the player the message is about, or the player whose turn it is for a
+	// turn message.
+	Player string `json:"player,omitempty"`
+	// Hand is the position of the hand the event is about, -1 for the dealer.
+	Hand int `json:"hand"`
+	// HandID is the ID of the hand the event is about, absent if the event is not about
+	// a player's hand.
+	HandID int `json:"handId,omitempty"`
+	// Seat is the seat a player has joined for a seated message.
+	Seat int `json:"seat,omitempty"`
+	// Card is the card dealt, absent when the card was dealt face down.
+	Card *cards.Card `json:"card,omitempty"`
+	// Cards is the dealer's hand when it is revealed.
+	Cards blackjack.Hand `json:"cards,omitempty"`
+	// Action is the action taken on the hand.
+	Action string `json:"action,omitempty"`
+	// Wager is the wager placed on the hand.
+	Wager int `json:"wager,omitempty"`
+	// Bet is the position of the bet of a Multi-Action hand a hand was settled for, or
+	// of the dealer's hand drawn for the bet a card was dealt to.
+	Bet int `json:"bet,omitempty"`
+	// State is the WinState of a settled hand.
+	State *blackjack.WinState `json:"state,omitempty"`
+	// Payout is the change to the player's winnings when a hand is settled, or to the
+	// backer's winnings when a back bet is settled.
+	Payout int `json:"payout,omitempty"`
+	// Backer is the player who placed a back bet on the hand.
+	Backer string `json:"backer,omitempty"`
+	// SideBet is the name of the side bet wagered on.
+	SideBet string `json:"sideBet,omitempty"`
+	// Outcome is the paytable outcome of a settled side bet, absent when it lost.
+	Outcome string `json:"outcome,omitempty"`
+	// Deadline is the time the player's turn will time out for a turn message, absent
+	// when the table has no turn timeout.
+	Deadline *time.Time `json:"deadline,omitempty"`
+	// Table is the state of the table as seen by the client for a snapshot.
+	Table *TableState `json:"table,omitempty"`
+	// Error is the reason the client's last request was refused.
+	Error *Error `json:"error,omitempty"`
+}
+
+// Request is sent by a client over a WebSocket to bet or act on their hand.
+type Request struct {
+	// Bet is the wager for the next round.
+	Bet int `json:"bet,omitempty"`
+	// Seat is the seat to bet on, zero to bet on every seat of the player.
+	Seat int `json:"seat,omitempty"`
+	// Action is the action to take on the current hand.
+	Action string `json:"action,omitempty"`
+}
+
+// subscriberBuffer is the number of messages a slow client may fall behind before it is
+// disconnected and has to resync.
+const subscriberBuffer = 64
+
+var upgrader = websocket.Upgrader{}
+
+// subscriber is the channel of messages pushed to a single client. Messages pushed
+// before the snapshot of the table are held back until the snapshot has been sent.
+type subscriber struct {
+	mu      sync.Mutex
+	ch      chan Message
+	seq     int
+	started bool
+	pending []Message
+	closed  bool
+}
+
+// start sends the snapshot followed by the messages held back for it.
+func (s *subscriber) start(snapshot Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = true
+	s.send(snapshot)
+	for _, m := range s.pending {
+		s.send(m)
+	}
+	s.pending = nil
+}
+
+// push sends the message once the subscriber has started.
+func (s *subscriber) push(m Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started {
+		s.pending = append(s.pending, m)
+		return
+	}
+	s.send(m)
+}
+
+// send sends the message without blocking. A subscriber that has fallen too far behind
+// is disconnected, and has to reconnect to resync from a snapshot. Messages already part
+// of the snapshot are skipped, and a message without a sequence number repeats the
+// sequence number of the message before it. The lock of the subscriber must be held.
+func (s *subscriber) send(m Message) {
+	if s.closed || (m.Seq != 0 && m.Seq <= s.seq) {
+		return
+	}
+	if m.Seq == 0 {
+		m.Seq = s.seq
+	}
+	s.seq = m.Seq
+	select {
+	case s.ch <- m:
+	default:
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// close stops sending messages to the channel and closes it.
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// serveWS pushes the messages of the table to the client until it disconnects, and
+// takes the bets and actions the client sends on behalf of the player named in the
+// query.
+func (s *Server) serveWS(w http.ResponseWriter, r *http.Request, t *table) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	player := r.URL.Query().Get("player")
+
+	sub := &subscriber{ch: make(chan Message, subscriberBuffer)}
+	variant := t.Rules().Variant
+	state, cancel := t.Subscribe(player, func(e blackjack.TableEvent) {
+		sub.push(message(e, variant))
+	})
+	sub.start(Message{Seq: state.Seq, Type: "snapshot", Player: player, Hand: -1, Table: &TableState{ID: t.id, TableState: state}})
+	defer cancel()
+	defer sub.close()
+
+	go func() {
+		defer conn.Close()
+		for m := range sub.ch {
+			if err := conn.WriteJSON(m); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		var req Request
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		if req.Action != "" {
+			err = t.act(player, req.Action)
+		} else {
+			err = t.bet(player, req.Seat, req.Bet)
+		}
+		if err != nil {
+			e, ok := err.(*Error)
+			if !ok {
+				e = errorf(http.StatusInternalServerError, "internal", "%v", err)
+			}
+			sub.push(Message{Type: "error", Player: player, Hand: -1, Error: e})
+		}
+	}
+}
+
+// message converts an event of the table into a Message, naming actions in the
+// vocabulary of the table's variant. The dealer's face down cards are never included.
+func message(te blackjack.TableEvent, variant blackjack.Variant) Message {
+	m := Message{Seq: te.Seq, Type: te.Event.Name(), Hand: te.Hand, HandID: te.HandID}
+	hand := func(val *blackjack.ListVal) {
+		if val != nil {
+			m.Player = val.Player.Name
+		}
+	}
+
+	switch e := te.Event.(type) {
+	case blackjack.CardDealt:
+		hand(e.Hand)
+		if e.FaceUp {
+			card := e.Card
+			m.Card = &card
+		}
+		m.Bet = e.Bet
+	case blackjack.BetPlaced:
+		hand(e.Hand)
+		m.Wager = e.Wager
+	case blackjack.ActionTaken:
+		hand(e.Hand)
+		m.Action = variant.ActionName(e.Action)
+	case blackjack.HandBusted:
+		hand(e.Hand)
+	case blackjack.HandSettled:
+		hand(e.Hand)
+		state := e.State
+		m.State = &state
+		m.Payout = e.Payout
+		m.Bet = e.Bet
+	case blackjack.BackBetPlaced:
+		hand(e.Hand)
+		m.Backer = e.Bet.Player.Name
+		m.Wager = e.Bet.Wager
+	case blackjack.BackBetSettled:
+		hand(e.Hand)
+		m.Backer = e.Bet.Player.Name
+		state := e.State
+		m.State = &state
+		m.Payout = e.Payout
+	case blackjack.SideBetPlaced:
+		hand(e.Hand)
+		m.SideBet = e.Bet.Bet.Name()
+		m.Wager = e.Bet.Wager
+	case blackjack.SideBetSettled:
+		hand(e.Hand)
+		m.SideBet = e.Bet.Bet.Name()
+		m.Outcome = e.Bet.Outcome
+		m.Payout = e.Bet.Payout
+	case blackjack.DealerRevealed:
+		m.Cards = e.Hand
+	case blackjack.PlayerSeated:
+		m.Type = "seated"
+		m.Player = e.Player
+		m.Seat = e.Seat
+	case blackjack.PlayerLeft:
+		m.Type = "left"
+		m.Player = e.Player
+	case blackjack.TurnStarted:
+		m.Type = "turn"
+		m.Player = e.Player
+		if !e.Deadline.IsZero() {
+			deadline := e.Deadline
+			m.Deadline = &deadline
+		}
+	case blackjack.TurnTimedOut:
+		m.Type = "timeout"
+		m.Player = e.Player
+		if e.Action != 0 {
+			m.Action = variant.ActionName(e.Action)
+		}
+	case blackjack.PlayerSatOut:
+		m.Type = "sitout"
+		m.Player = e.Player
+	}
+	return m
+}
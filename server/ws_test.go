@@ -0,0 +1,109 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dial(t *testing.T, ts *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + path
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("could not dial %s: %v", path, err)
+	}
+	return conn
+}
+
+// next reads messages until one of the specified type is received.
+func next(t *testing.T, conn *websocket.Conn, typ string) Message {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var m Message
+		if err := conn.ReadJSON(&m); err != nil {
+			t.Fatalf("expected a %s message but got %v", typ, err)
+		}
+		if m.Type == typ {
+			return m
+		}
+	}
+}
+
+func TestServerWebSocket(t *testing.T) {
+	s := New()
+	s.Seed = func() int64 { return 1 }
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	c := client{t: t, url: ts.URL}
+
+	var table TableState
+	c.do("POST", "/tables", map[string]int{"decks": 1}, &table)
+	path := "/tables/" + table.ID
+	c.do("POST", path+"/players", map[string]string{"name": "a"}, nil)
+	c.do("POST", path+"/players", map[string]string{"name": "b"}, nil)
+
+	a := dial(t, ts, path+"/ws?player=a")
+	defer a.Close()
+	b := dial(t, ts, path+"/ws?player=b")
+
+	snapshot := next(t, a, "snapshot")
+	if snapshot.Table == nil || len(snapshot.Table.Seats) != 2 {
+		t.Fatalf("expected the first message to be a snapshot of the table with two seats")
+	}
+	next(t, b, "snapshot")
+
+	a.WriteJSON(Request{Bet: 5})
+	b.WriteJSON(Request{Bet: 5})
+
+	var hole int
+	a.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var m Message
+		if err := a.ReadJSON(&m); err != nil {
+			t.Fatalf("expected the round to be pushed but got %v", err)
+		}
+		if m.Type == "CardDealt" && m.Hand == -1 && m.Card == nil {
+			hole++
+		}
+		if m.Type == "turn" {
+			if m.Player != "a" {
+				t.Fatalf("expected a turn message for player a but was for %q", m.Player)
+			}
+			break
+		}
+	}
+	if hole != 1 {
+		t.Fatalf("expected the dealer's hole card to be pushed face down once but was %d times", hole)
+	}
+
+	b.WriteJSON(Request{Action: "stay"})
+	if m := next(t, b, "error"); m.Error == nil || m.Error.Code != "not_your_turn" {
+		t.Fatalf("expected b acting out of turn to be refused")
+	}
+
+	a.WriteJSON(Request{Action: "stay"})
+	if m := next(t, b, "turn"); m.Player != "b" {
+		t.Fatalf("expected b to be pushed a turn message after a stays but was %q", m.Player)
+	}
+
+	b.Close()
+	b = dial(t, ts, path+"/ws?player=b")
+	defer b.Close()
+	resync := next(t, b, "snapshot")
+	if resync.Table.Turn != "b" || resync.Seq < snapshot.Seq {
+		t.Fatalf("expected a reconnecting client to resync from a snapshot of the current turn")
+	}
+
+	b.WriteJSON(Request{Action: "stay"})
+	if m := next(t, a, "HandSettled"); m.State == nil {
+		t.Fatalf("expected settlements to be pushed with the state of the hand")
+	}
+	if m := next(t, a, "turn"); m.Player != "" {
+		t.Fatalf("expected no player's turn once the round is settled but was %q", m.Player)
+	}
+}
@@ -0,0 +1,225 @@
+package blackjack
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ethanefung/cards"
+)
+
+// SideBetTiming is when a side bet is decided. Every side bet is settled when the dealer
+// collects, but a side bet decided after the deal only sees the dealer's cards that were
+// face up once the first cards of the round were dealt.
+type SideBetTiming int
+
+const (
+	// AfterDeal side bets are decided by the hand's first two cards and the dealer's face
+	// up card.
+	AfterDeal SideBetTiming = iota
+	// AtRoundEnd side bets are decided by the hand's first two cards and the dealer's
+	// final hand.
+	AtRoundEnd
+)
+
+// SideBet is an optional wager placed on a hand alongside its main wager that is paid
+// from a paytable rather than by beating the dealer.
+type SideBet interface {
+	// Name returns the name of the side bet, such as "21+3". A hand may carry one wager
+	// on each side bet name.
+	Name() string
+	// Timing returns when the side bet is decided.
+	Timing() SideBetTiming
+	// Settle returns the outcome of a wager on the side bet and the change to the
+	// player's winnings. The outcome is empty when the wager loses.
+	Settle(r SideBetRound, wager int) (outcome string, payout int)
+}
+
+// SideBetPlacer is implemented by side bets that are told of every wager placed on them,
+// such as to contribute to a jackpot.
+type SideBetPlacer interface {
+	// Place is called with every wager on the side bet before it is placed. Place returns
+	// false to refuse the wager.
+	Place(wager int) bool
+}
+
+// UpcardSideBet is implemented by side bets decided by the dealer's face up card, which
+// are refused in variants that deal the dealer's first cards face down.
+type UpcardSideBet interface {
+	// UsesUpcard returns true if the side bet is decided by the dealer's face up card.
+	UsesUpcard() bool
+}
+
+// SideBetRound is what a side bet is settled on.
+type SideBetRound struct {
+	// Hand is the hand the side bet was placed on.
+	Hand *ListVal
+	// Cards are the first two cards dealt to the hand, which are kept when the hand is
+	// split.
+	Cards Hand
+	// Dealer is the dealer's face up cards for a side bet decided after the deal, or the
+	// dealer's final hand, hole card first, for a side bet settled at the end of the
+	// round.
+	Dealer Hand
+	// Upcard is the dealer's face up card, the second card dealt to the dealer, or the
+	// zero Card if it was dealt face down.
+	Upcard cards.Card
+}
+
+// SideWager is a wager on a side bet placed on a hand.
+type SideWager struct {
+	// Bet is the side bet wagered on.
+	Bet SideBet
+	// Wager is the amount wagered.
+	Wager int
+	// Settled is whether the wager has been settled.
+	Settled bool
+	// Outcome is the outcome of the wager once settled, empty when it lost.
+	Outcome string
+	// Payout is the change to the player's winnings once settled.
+	Payout int
+
+	cards Hand
+}
+
+// SideBetResult is a SideWager as reported in GameState and View.
+type SideBetResult struct {
+	// Name is the name of the side bet.
+	Name string `json:"name"`
+	// Wager is the amount wagered.
+	Wager int `json:"wager"`
+	// Settled is whether the wager has been settled.
+	Settled bool `json:"settled"`
+	// Outcome is the outcome of the wager once settled, empty when it lost.
+	Outcome string `json:"outcome,omitempty"`
+	// Payout is the change to the player's winnings once settled.
+	Payout int `json:"payout"`
+}
+
+// Result returns the wager as reported in GameState and View.
+func (w *SideWager) Result() SideBetResult {
+	return SideBetResult{
+		Name:    w.Bet.Name(),
+		Wager:   w.Wager,
+		Settled: w.Settled,
+		Outcome: w.Outcome,
+		Payout:  w.Payout,
+	}
+}
+
+// Paytable is what each outcome of a side bet pays to one, such as 9 for an outcome
+// that pays 9 to 1.
+type Paytable map[string]int
+
+// Pay returns the outcome along with the change to a player's winnings for the wager.
+// Outcomes missing from the paytable lose the wager.
+func (p Paytable) Pay(outcome string, wager int) (string, int) {
+	if odds, ok := p[outcome]; ok && outcome != "" {
+		return outcome, odds * wager
+	}
+	return "", -wager
+}
+
+// SideBet places a wager on the side bet on the specified hand. SideBet returns false if
+// the hand has already been dealt, the wager is not positive, the hand already carries
+// a wager on a side bet of the same name, the side bet is decided by the dealer's face
+// up card in a variant without one or the side bet refuses the wager.
+func (d *Dealer) SideBet(listVal *ListVal, bet SideBet, wager int) bool {
+	if listVal == nil || bet == nil || len(listVal.Hand) > 0 || wager <= 0 {
+		return false
+	}
+	v := d.Game.Rules.variant()
+	if u, ok := bet.(UpcardSideBet); ok && u.UsesUpcard() && v.hidden(0) && v.hidden(1) {
+		return false
+	}
+	for _, w := range listVal.SideBets {
+		if !w.Settled && w.Bet.Name() == bet.Name() {
+			return false
+		}
+	}
+	if placer, ok := bet.(SideBetPlacer); ok && !placer.Place(wager) {
+		return false
+	}
+	w := &SideWager{Bet: bet, Wager: wager}
+	listVal.SideBets = append(listVal.SideBets, w)
+	d.Game.emit(SideBetPlaced{Hand: listVal, Bet: w})
+	return true
+}
+
+// keepSideBetCards keeps the first two cards of every hand carrying side bets, which
+// splitting the hand would change.
+func (d *Dealer) keepSideBetCards() {
+	for _, val := range d.Game.Hands().All() {
+		for _, w := range val.SideBets {
+			if !w.Settled && w.cards == nil && len(val.Hand) >= 2 {
+				w.cards = append(Hand{}, val.Hand[:2]...)
+			}
+		}
+	}
+}
+
+// settleSideBets settles the side bets of every hand that has been dealt. Side bets
+// decided after the deal see the dealer's face up cards among the first two dealt.
+func (d *Dealer) settleSideBets() {
+	d.keepSideBetCards()
+	v := d.Game.Rules.variant()
+	upcards := Hand{}
+	for i, card := range d.hand {
+		if i < 2 && !v.hidden(i) {
+			upcards = append(upcards, card)
+		}
+	}
+	var upcard cards.Card
+	if len(d.hand) >= 2 && !v.hidden(1) {
+		upcard = d.hand[1]
+	}
+	for _, val := range d.Game.Hands().All() {
+		for _, w := range val.SideBets {
+			if w.Settled || w.cards == nil {
+				continue
+			}
+			dealer := d.hand
+			if w.Bet.Timing() == AfterDeal {
+				dealer = upcards
+			}
+			r := SideBetRound{Hand: val, Cards: w.cards, Dealer: dealer, Upcard: upcard}
+			w.Outcome, w.Payout = w.Bet.Settle(r, w.Wager)
+			w.Settled = true
+			val.Player.Winnings += w.Payout
+			d.Game.emit(SideBetSettled{Hand: val, Bet: w})
+		}
+	}
+}
+
+var (
+	sideBetsMu sync.Mutex
+	sideBets   = make(map[string]SideBet)
+)
+
+// RegisterSideBet makes the side bet available by its name to LookupSideBet and to
+// Restore, which restores the side bets of a snapshot by name. The side bets of this
+// package are registered with their default paytables.
+func RegisterSideBet(bet SideBet) {
+	sideBetsMu.Lock()
+	defer sideBetsMu.Unlock()
+	sideBets[bet.Name()] = bet
+}
+
+// LookupSideBet returns the side bet registered with the specified name.
+func LookupSideBet(name string) (SideBet, bool) {
+	sideBetsMu.Lock()
+	defer sideBetsMu.Unlock()
+	bet, ok := sideBets[name]
+	return bet, ok
+}
+
+// SideBetNames returns the names of every registered side bet in alphabetical order.
+func SideBetNames() []string {
+	sideBetsMu.Lock()
+	defer sideBetsMu.Unlock()
+	names := make([]string, 0, len(sideBets))
+	for name := range sideBets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,121 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestDealerSideBet(t *testing.T) {
+	game := New()
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	val := game.Players.Head
+
+	if !game.Dealer.SideBet(val, PerfectPairs{}, 5) {
+		t.Fatalf("expected a side bet before the deal to be placed")
+	}
+	if game.Dealer.SideBet(val, PerfectPairs{}, 5) || game.Dealer.SideBet(val, RoyalMatch{}, 0) {
+		t.Fatalf("expected a second wager on the same side bet and a wager of zero to be refused")
+	}
+	game.Dealer.SideBet(val, LuckyLadies{}, 5)
+
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Ten, Suit: cards.Hearts},
+		{Rank: cards.Ace, Suit: cards.Spades},
+		{Rank: cards.Ten, Suit: cards.Hearts},
+		{Rank: cards.King, Suit: cards.Spades},
+	})
+	var settled []SideBetSettled
+	game.Listen(func(e Event) {
+		if s, ok := e.(SideBetSettled); ok {
+			settled = append(settled, s)
+		}
+	})
+	game.Dealer.Deal(2, game.Players)
+	if game.Dealer.SideBet(val, RoyalMatch{}, 5) {
+		t.Fatalf("expected a side bet after the deal to be refused")
+	}
+	if len(settled) != 0 || a.Winnings != 0 {
+		t.Fatalf("expected no side bet to be settled before the dealer collected")
+	}
+
+	game.Start()
+	game.Dealer.Stay()
+	game.Dealer.Play()
+	game.Dealer.Collect()
+	if len(settled) != 2 || settled[0].Bet.Outcome != PerfectPair || settled[1].Bet.Outcome != Matched20 {
+		t.Fatalf("expected both side bets to be settled when the dealer collected")
+	}
+	// 125 for the perfect pair, 125 for the matched 20 and nothing for the main hand
+	// lost to the dealer's blackjack
+	if a.Winnings != 250 {
+		t.Fatalf("expected a to have won 250 but was %d", a.Winnings)
+	}
+
+	results := game.State().SideBets[val.ID]
+	if len(results) != 2 || !results[1].Settled || results[1].Payout != 125 {
+		t.Fatalf("expected the side bets of the hand to be reported in the game state")
+	}
+
+	game.Dealer.Clear()
+	if len(val.SideBets) != 0 || !game.Dealer.SideBet(val, RoyalMatch{}, 5) {
+		t.Fatalf("expected the settled side bets to be dropped and new ones taken for the next round")
+	}
+	game.Dealer.Clear()
+	if len(val.SideBets) != 1 || val.SideBets[0].Bet.Name() != "Royal Match" {
+		t.Fatalf("expected clearing the table to only drop the settled side bets")
+	}
+}
+
+func TestGameSnapshotSideBets(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	game.Dealer.SideBet(game.Players.Head, LuckyLadies{}, 5)
+
+	restored, err := Restore(game.Snapshot())
+	if err != nil {
+		t.Fatalf("expected the snapshot to be restored but got %v", err)
+	}
+	w := restored.Players.Head.SideBets
+	if len(w) != 1 || w[0].Bet.Name() != "Lucky Ladies" || w[0].Wager != 5 {
+		t.Fatalf("expected the side bet to be restored")
+	}
+
+	s := game.Snapshot()
+	s.Hands[0].SideBets[0].Name = "Unknown"
+	if _, err := Restore(s); err == nil {
+		t.Fatalf("expected a snapshot with an unknown side bet to be refused")
+	}
+}
+
+func TestDealerBusterSideBet(t *testing.T) {
+	game := New()
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	game.Dealer.SideBet(game.Players.Head, Buster{}, 5)
+
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Ace, Suit: cards.Spades},
+		{Rank: cards.Six, Suit: cards.Hearts},
+		{Rank: cards.King, Suit: cards.Spades},
+		{Rank: cards.Two, Suit: cards.Hearts},
+		{Rank: cards.Two, Suit: cards.Clubs},
+		{Rank: cards.Two, Suit: cards.Diamonds},
+		{Rank: cards.Three, Suit: cards.Clubs},
+		{Rank: cards.Ten, Suit: cards.Clubs},
+	})
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+	game.Dealer.Stay()
+	game.Dealer.Play()
+
+	if dealer := game.State().DealerHand; len(dealer) != 6 || dealer.Value() != 25 {
+		t.Fatalf("expected the game state to show the dealer's final hand of six cards")
+	}
+	game.Dealer.Collect()
+	w := game.Players.Head.SideBets[0]
+	if w.Outcome != Bust6Cards || w.Payout != 500 {
+		t.Fatalf("expected the natural to be paid 100 to 1 for a six card bust but was %q paying %d", w.Outcome, w.Payout)
+	}
+}
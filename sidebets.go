@@ -0,0 +1,303 @@
+package blackjack
+
+import (
+	"sort"
+
+	"github.com/ethanefung/cards"
+)
+
+func init() {
+	RegisterSideBet(TwentyOnePlusThree{})
+	RegisterSideBet(PerfectPairs{})
+	RegisterSideBet(LuckyLadies{})
+	RegisterSideBet(RoyalMatch{})
+	RegisterSideBet(OverUnder13{Over: true})
+	RegisterSideBet(OverUnder13{})
+	RegisterSideBet(Buster{})
+}
+
+// The outcomes of the side bets of this package.
+const (
+	SuitedTrips   = "Suited Trips"
+	StraightFlush = "Straight Flush"
+	ThreeOfAKind  = "Three of a Kind"
+	Straight      = "Straight"
+	Flush         = "Flush"
+
+	PerfectPair = "Perfect Pair"
+	ColoredPair = "Colored Pair"
+	MixedPair   = "Mixed Pair"
+
+	QueenOfHeartsWithBlackjack = "Queen of Hearts Pair with Dealer Blackjack"
+	QueenOfHeartsPair          = "Queen of Hearts Pair"
+	Matched20                  = "Matched 20"
+	Suited20                   = "Suited 20"
+	Any20                      = "Any 20"
+
+	RoyalMatchOutcome = "Royal Match"
+	EasyMatch         = "Easy Match"
+
+	Over13  = "Over 13"
+	Under13 = "Under 13"
+
+	Bust3Cards = "3 Card Bust"
+	Bust4Cards = "4 Card Bust"
+	Bust5Cards = "5 Card Bust"
+	Bust6Cards = "6 Card Bust"
+	Bust7Cards = "7 Card Bust"
+	Bust8Cards = "8 or More Card Bust"
+)
+
+var (
+	// TwentyOnePlusThreePays is the default paytable of TwentyOnePlusThree.
+	TwentyOnePlusThreePays = Paytable{SuitedTrips: 100, StraightFlush: 40, ThreeOfAKind: 30, Straight: 10, Flush: 5}
+	// PerfectPairsPays is the default paytable of PerfectPairs.
+	PerfectPairsPays = Paytable{PerfectPair: 25, ColoredPair: 12, MixedPair: 6}
+	// LuckyLadiesPays is the default paytable of LuckyLadies.
+	LuckyLadiesPays = Paytable{QueenOfHeartsWithBlackjack: 1000, QueenOfHeartsPair: 200, Matched20: 25, Suited20: 10, Any20: 4}
+	// RoyalMatchPays is the default paytable of RoyalMatch.
+	RoyalMatchPays = Paytable{RoyalMatchOutcome: 25, EasyMatch: 3}
+	// OverUnder13Pays is the default paytable of OverUnder13.
+	OverUnder13Pays = Paytable{Over13: 1, Under13: 1}
+	// BusterPays is the default paytable of Buster.
+	BusterPays = Paytable{Bust3Cards: 1, Bust4Cards: 2, Bust5Cards: 4, Bust6Cards: 12, Bust7Cards: 50, Bust8Cards: 250}
+	// BusterBlackjackPays is the default paytable of Buster for a hand dealt a natural.
+	BusterBlackjackPays = Paytable{Bust3Cards: 2, Bust4Cards: 3, Bust5Cards: 15, Bust6Cards: 100, Bust7Cards: 800, Bust8Cards: 2000}
+)
+
+// or returns the paytable, or the default paytable if it is nil.
+func (p Paytable) or(def Paytable) Paytable {
+	if p == nil {
+		return def
+	}
+	return p
+}
+
+// TwentyOnePlusThree pays when the hand's first two cards and the dealer's face up card
+// make a three card poker hand.
+type TwentyOnePlusThree struct {
+	// Pays is the paytable, TwentyOnePlusThreePays if nil.
+	Pays Paytable
+}
+
+func (TwentyOnePlusThree) Name() string          { return "21+3" }
+func (TwentyOnePlusThree) Timing() SideBetTiming { return AfterDeal }
+func (TwentyOnePlusThree) UsesUpcard() bool      { return true }
+
+// Settle pays the poker hand made by the hand's first two cards and the dealer's face up
+// card, which is the first of the dealer's cards when the round carries no Upcard.
+func (b TwentyOnePlusThree) Settle(r SideBetRound, wager int) (string, int) {
+	upcard := r.Upcard
+	if upcard == (cards.Card{}) && len(r.Dealer) > 0 {
+		upcard = r.Dealer[0]
+	}
+	if len(r.Cards) < 2 || upcard == (cards.Card{}) {
+		return "", -wager
+	}
+	return b.Pays.or(TwentyOnePlusThreePays).Pay(pokerHand(r.Cards[0], r.Cards[1], upcard), wager)
+}
+
+// pokerHand returns the best three card poker hand of the cards, or an empty string if
+// the cards make no hand.
+func pokerHand(c ...cards.Card) string {
+	flush := c[0].Suit == c[1].Suit && c[1].Suit == c[2].Suit
+	trips := c[0].Rank == c[1].Rank && c[1].Rank == c[2].Rank
+	ranks := []int{int(c[0].Rank), int(c[1].Rank), int(c[2].Rank)}
+	sort.Ints(ranks)
+	straight := ranks[0]+1 == ranks[1] && ranks[1]+1 == ranks[2]
+	// aces are also high, as in queen, king, ace
+	if ranks[0] == int(cards.Ace) && ranks[1] == int(cards.Queen) && ranks[2] == int(cards.King) {
+		straight = true
+	}
+
+	switch {
+	case trips && flush:
+		return SuitedTrips
+	case straight && flush:
+		return StraightFlush
+	case trips:
+		return ThreeOfAKind
+	case straight:
+		return Straight
+	case flush:
+		return Flush
+	}
+	return ""
+}
+
+// PerfectPairs pays when the hand's first two cards are a pair.
+type PerfectPairs struct {
+	// Pays is the paytable, PerfectPairsPays if nil.
+	Pays Paytable
+}
+
+func (PerfectPairs) Name() string          { return "Perfect Pairs" }
+func (PerfectPairs) Timing() SideBetTiming { return AfterDeal }
+
+// Settle pays a pair of the same suit, of the same color or of mixed colors.
+func (b PerfectPairs) Settle(r SideBetRound, wager int) (string, int) {
+	var outcome string
+	if len(r.Cards) >= 2 && r.Cards[0].Rank == r.Cards[1].Rank {
+		a, c := r.Cards[0], r.Cards[1]
+		switch {
+		case a.Suit == c.Suit:
+			outcome = PerfectPair
+		case red(a) == red(c):
+			outcome = ColoredPair
+		default:
+			outcome = MixedPair
+		}
+	}
+	return b.Pays.or(PerfectPairsPays).Pay(outcome, wager)
+}
+
+// red returns true if the card is a diamond or a heart.
+func red(c cards.Card) bool {
+	return c.Suit == cards.Diamonds || c.Suit == cards.Hearts
+}
+
+// LuckyLadies pays when the hand's first two cards total 20, and most for a pair of
+// queens of hearts when the dealer has blackjack. It is settled at the end of the round
+// so that the dealer's hole card is known.
+type LuckyLadies struct {
+	// Pays is the paytable, LuckyLadiesPays if nil.
+	Pays Paytable
+}
+
+func (LuckyLadies) Name() string          { return "Lucky Ladies" }
+func (LuckyLadies) Timing() SideBetTiming { return AtRoundEnd }
+
+// Settle pays a total of 20 by how well the two cards match.
+func (b LuckyLadies) Settle(r SideBetRound, wager int) (string, int) {
+	var outcome string
+	first := r.Cards
+	if len(first) > 2 {
+		first = first[:2]
+	}
+	if len(first) == 2 && first.Value() == 20 {
+		a, c := first[0], first[1]
+		queen := cards.Card{Rank: cards.Queen, Suit: cards.Hearts}
+		dealer := r.Dealer
+		if len(dealer) > 2 {
+			dealer = dealer[:2]
+		}
+		switch {
+		case a == queen && c == queen && len(dealer) == 2 && dealer.Value() == 21:
+			outcome = QueenOfHeartsWithBlackjack
+		case a == queen && c == queen:
+			outcome = QueenOfHeartsPair
+		case a == c:
+			outcome = Matched20
+		case a.Suit == c.Suit:
+			outcome = Suited20
+		default:
+			outcome = Any20
+		}
+	}
+	return b.Pays.or(LuckyLadiesPays).Pay(outcome, wager)
+}
+
+// RoyalMatch pays when the hand's first two cards are of the same suit, and most when
+// they are the king and queen.
+type RoyalMatch struct {
+	// Pays is the paytable, RoyalMatchPays if nil.
+	Pays Paytable
+}
+
+func (RoyalMatch) Name() string          { return "Royal Match" }
+func (RoyalMatch) Timing() SideBetTiming { return AfterDeal }
+
+// Settle pays two suited cards.
+func (b RoyalMatch) Settle(r SideBetRound, wager int) (string, int) {
+	var outcome string
+	if len(r.Cards) >= 2 && r.Cards[0].Suit == r.Cards[1].Suit {
+		outcome = EasyMatch
+		ranks := map[cards.Rank]bool{r.Cards[0].Rank: true, r.Cards[1].Rank: true}
+		if ranks[cards.King] && ranks[cards.Queen] {
+			outcome = RoyalMatchOutcome
+		}
+	}
+	return b.Pays.or(RoyalMatchPays).Pay(outcome, wager)
+}
+
+// OverUnder13 pays when the hand's first two cards total over 13, or under 13, counting
+// aces as one. A total of 13 loses either way.
+type OverUnder13 struct {
+	// Over is whether the wager is on a total over 13 rather than under.
+	Over bool
+	// Pays is the paytable, OverUnder13Pays if nil.
+	Pays Paytable
+}
+
+func (b OverUnder13) Name() string {
+	if b.Over {
+		return Over13
+	}
+	return Under13
+}
+
+func (OverUnder13) Timing() SideBetTiming { return AfterDeal }
+
+// Settle pays a total on the side of 13 that was wagered on.
+func (b OverUnder13) Settle(r SideBetRound, wager int) (string, int) {
+	var outcome string
+	if len(r.Cards) >= 2 {
+		total := 0
+		for _, c := range r.Cards[:2] {
+			if c.Rank == cards.Ace {
+				total++
+			} else {
+				total += cardValues[c.Rank]
+			}
+		}
+		if b.Over && total > 13 {
+			outcome = Over13
+		} else if !b.Over && total < 13 {
+			outcome = Under13
+		}
+	}
+	return b.Pays.or(OverUnder13Pays).Pay(outcome, wager)
+}
+
+// Buster pays when the dealer busts, by the number of cards in the dealer's final hand.
+// A hand dealt a natural is paid from the enhanced BlackjackPays paytable. It is settled
+// at the end of the round once the dealer has played.
+type Buster struct {
+	// Pays is the paytable, BusterPays if nil.
+	Pays Paytable
+	// BlackjackPays is the paytable for a hand dealt a natural, BusterBlackjackPays if
+	// nil.
+	BlackjackPays Paytable
+}
+
+func (Buster) Name() string          { return "Buster" }
+func (Buster) Timing() SideBetTiming { return AtRoundEnd }
+
+// Settle pays a dealer bust by the length of the dealer's final hand.
+func (b Buster) Settle(r SideBetRound, wager int) (string, int) {
+	var outcome string
+	if dealer := r.Dealer; dealer.Value() > 21 {
+		switch n := len(dealer); {
+		case n <= 3:
+			outcome = Bust3Cards
+		case n == 4:
+			outcome = Bust4Cards
+		case n == 5:
+			outcome = Bust5Cards
+		case n == 6:
+			outcome = Bust6Cards
+		case n == 7:
+			outcome = Bust7Cards
+		default:
+			outcome = Bust8Cards
+		}
+	}
+	first := r.Cards
+	if len(first) > 2 {
+		first = first[:2]
+	}
+	if len(first) == 2 && first.Value() == 21 && (r.Hand == nil || !r.Hand.Split) {
+		return b.BlackjackPays.or(BusterBlackjackPays).Pay(outcome, wager)
+	}
+	return b.Pays.or(BusterPays).Pay(outcome, wager)
+}
@@ -0,0 +1,63 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestSideBetOutcomes(t *testing.T) {
+	card := func(r cards.Rank, s cards.Suit) cards.Card {
+		return cards.Card{Rank: r, Suit: s}
+	}
+	tests := []struct {
+		bet     SideBet
+		cards   Hand
+		dealer  Hand
+		outcome string
+		payout  int
+	}{
+		{TwentyOnePlusThree{}, Hand{card(cards.Seven, cards.Hearts), card(cards.Seven, cards.Hearts)}, Hand{card(cards.Seven, cards.Hearts)}, SuitedTrips, 1000},
+		{TwentyOnePlusThree{}, Hand{card(cards.Queen, cards.Clubs), card(cards.King, cards.Clubs)}, Hand{card(cards.Ace, cards.Clubs)}, StraightFlush, 400},
+		{TwentyOnePlusThree{}, Hand{card(cards.Seven, cards.Hearts), card(cards.Seven, cards.Clubs)}, Hand{card(cards.Seven, cards.Spades)}, ThreeOfAKind, 300},
+		{TwentyOnePlusThree{}, Hand{card(cards.Ace, cards.Hearts), card(cards.Three, cards.Clubs)}, Hand{card(cards.Two, cards.Spades)}, Straight, 100},
+		{TwentyOnePlusThree{}, Hand{card(cards.Two, cards.Hearts), card(cards.Nine, cards.Hearts)}, Hand{card(cards.King, cards.Hearts)}, Flush, 50},
+		{TwentyOnePlusThree{}, Hand{card(cards.King, cards.Hearts), card(cards.Ace, cards.Clubs)}, Hand{card(cards.Two, cards.Hearts)}, "", -10},
+		{PerfectPairs{}, Hand{card(cards.Eight, cards.Spades), card(cards.Eight, cards.Spades)}, nil, PerfectPair, 250},
+		{PerfectPairs{}, Hand{card(cards.Eight, cards.Hearts), card(cards.Eight, cards.Diamonds)}, nil, ColoredPair, 120},
+		{PerfectPairs{}, Hand{card(cards.Eight, cards.Hearts), card(cards.Eight, cards.Clubs)}, nil, MixedPair, 60},
+		{PerfectPairs{Pays: Paytable{PerfectPair: 30}}, Hand{card(cards.Eight, cards.Hearts), card(cards.Eight, cards.Clubs)}, nil, "", -10},
+		{LuckyLadies{}, Hand{card(cards.Queen, cards.Hearts), card(cards.Queen, cards.Hearts)}, Hand{card(cards.Ace, cards.Spades), card(cards.King, cards.Spades)}, QueenOfHeartsWithBlackjack, 10000},
+		{LuckyLadies{}, Hand{card(cards.Queen, cards.Hearts), card(cards.Queen, cards.Hearts)}, Hand{card(cards.Ten, cards.Spades), card(cards.Six, cards.Spades), card(cards.Five, cards.Spades)}, QueenOfHeartsPair, 2000},
+		{LuckyLadies{}, Hand{card(cards.Jack, cards.Clubs), card(cards.Jack, cards.Clubs)}, nil, Matched20, 250},
+		{LuckyLadies{}, Hand{card(cards.Ace, cards.Clubs), card(cards.Nine, cards.Clubs)}, nil, Suited20, 100},
+		{LuckyLadies{}, Hand{card(cards.King, cards.Clubs), card(cards.Ten, cards.Hearts)}, nil, Any20, 40},
+		{RoyalMatch{}, Hand{card(cards.King, cards.Spades), card(cards.Queen, cards.Spades)}, nil, RoyalMatchOutcome, 250},
+		{RoyalMatch{}, Hand{card(cards.Two, cards.Spades), card(cards.Queen, cards.Spades)}, nil, EasyMatch, 30},
+		{OverUnder13{Over: true}, Hand{card(cards.King, cards.Spades), card(cards.Four, cards.Hearts)}, nil, Over13, 10},
+		{OverUnder13{}, Hand{card(cards.Ace, cards.Spades), card(cards.King, cards.Hearts)}, nil, Under13, 10},
+		{OverUnder13{Over: true}, Hand{card(cards.Ten, cards.Spades), card(cards.Three, cards.Hearts)}, nil, "", -10},
+		{Buster{}, Hand{card(cards.Ten, cards.Spades), card(cards.Nine, cards.Hearts)}, Hand{card(cards.Six, cards.Spades), card(cards.Ten, cards.Hearts), card(cards.King, cards.Hearts)}, Bust3Cards, 10},
+		{Buster{}, Hand{card(cards.Ten, cards.Spades), card(cards.Nine, cards.Hearts)}, Hand{card(cards.Two, cards.Spades), card(cards.Two, cards.Hearts), card(cards.Two, cards.Clubs), card(cards.Ace, cards.Clubs), card(cards.Three, cards.Clubs), card(cards.Five, cards.Clubs), card(cards.Ten, cards.Clubs)}, Bust7Cards, 500},
+		{Buster{}, Hand{card(cards.Ace, cards.Spades), card(cards.King, cards.Hearts)}, Hand{card(cards.Six, cards.Spades), card(cards.Two, cards.Hearts), card(cards.Three, cards.Hearts), card(cards.Two, cards.Clubs), card(cards.Three, cards.Clubs), card(cards.King, cards.Clubs)}, Bust6Cards, 1000},
+		{Buster{}, Hand{card(cards.Ace, cards.Spades), card(cards.King, cards.Hearts)}, Hand{card(cards.Ten, cards.Spades), card(cards.Seven, cards.Hearts)}, "", -10},
+	}
+
+	for _, test := range tests {
+		outcome, payout := test.bet.Settle(SideBetRound{Cards: test.cards, Dealer: test.dealer}, 10)
+		if outcome != test.outcome || payout != test.payout {
+			t.Errorf("expected %s on %v to be %q paying %d but was %q paying %d", test.bet.Name(), test.cards, test.outcome, test.payout, outcome, payout)
+		}
+	}
+}
+
+func TestLookupSideBet(t *testing.T) {
+	for _, name := range []string{"21+3", "Perfect Pairs", "Lucky Ladies", "Royal Match", "Over 13", "Under 13", "Buster"} {
+		if bet, ok := LookupSideBet(name); !ok || bet.Name() != name {
+			t.Fatalf("expected the side bet %s to be registered", name)
+		}
+	}
+	if len(SideBetNames()) < 7 {
+		t.Fatalf("expected every side bet of the package to be named")
+	}
+}
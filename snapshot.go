@@ -0,0 +1,308 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethanefung/cards"
+)
+
+// Snapshot is the complete state of a Game without any pointers, so that a table can
+// be persisted and resumed. A Snapshot includes the dealer's hole card and the order of
+// the shoe, so it should never be sent to players.
+type Snapshot struct {
+	// Rules are the table rules of the game.
+	Rules Rules `json:"rules"`
+	// Players are the players seated at the table and the owners of the hands.
+	Players []PlayerSnapshot `json:"players"`
+	// Seats are the index of the player at each seat within Players from first base to
+	// third base, or -1 for an empty seat.
+	Seats []int `json:"seats,omitempty"`
+	// Hands are the hands of Game.Players in order.
+	Hands []HandSnapshot `json:"hands"`
+	// Current is the index of Game.Current within Hands, or -1 if there is no current
+	// hand.
+	Current int `json:"current"`
+	// Dealer is the state of the dealer.
+	Dealer DealerSnapshot `json:"dealer"`
+	// LastID is the last ID given to a hand, including hands that have since been
+	// removed, so that IDs are never reused once the game is restored.
+	LastID int `json:"lastId,omitempty"`
+}
+
+// PlayerSnapshot is the state of a Player.
+type PlayerSnapshot struct {
+	// Name is the name of the player.
+	Name string `json:"name"`
+	// Winnings are the winnings of the player.
+	Winnings int `json:"winnings"`
+}
+
+// HandSnapshot is the state of a ListVal.
+type HandSnapshot struct {
+	// ID is the ID of the hand.
+	ID int `json:"id"`
+	// Player is the index of the hand's owner within Snapshot.Players.
+	Player int `json:"player"`
+	// Hand is the cards of the hand.
+	Hand Hand `json:"hand"`
+	// Wager is the wager placed on the hand.
+	Wager int `json:"wager"`
+	// Extra are the wagers after Wager of a Multi-Action hand.
+	Extra []int `json:"extra,omitempty"`
+	// Free is the wager put up by the house for a free double or split of the hand.
+	Free int `json:"free,omitempty"`
+	// Split is whether the hand was added during the round.
+	Split bool `json:"split"`
+	// Doubled is whether the hand was doubled during the round.
+	Doubled bool `json:"doubled,omitempty"`
+	// Second is whether the hand is the second hand of a seat in Blackjack Switch.
+	Second bool `json:"second,omitempty"`
+	// Switched is whether the second card of the hand was switched in Blackjack Switch.
+	Switched bool `json:"switched,omitempty"`
+	// Seat is the seat the hand is played from, zero at a table without seats.
+	Seat int `json:"seat,omitempty"`
+	// BackBets are the wagers placed on the hand by other players.
+	BackBets []BackBetSnapshot `json:"backBets,omitempty"`
+	// SideBets are the wagers on side bets placed on the hand.
+	SideBets []SideBetSnapshot `json:"sideBets,omitempty"`
+}
+
+// BackBetSnapshot is the state of a BackBet.
+type BackBetSnapshot struct {
+	// Player is the index of the back bet's player within Snapshot.Players.
+	Player int `json:"player"`
+	// Wager is the wager of the back bet.
+	Wager int `json:"wager"`
+	// FollowDouble is whether the wager is doubled when the hand is doubled.
+	FollowDouble bool `json:"followDouble"`
+	// FollowSplit is whether the wager follows the hand when it is split.
+	FollowSplit bool `json:"followSplit"`
+}
+
+// SideBetSnapshot is the state of a SideWager. The side bet is restored by its name from
+// the registered side bets.
+type SideBetSnapshot struct {
+	SideBetResult
+	// Cards are the first two cards of the hand the side bet is settled on, empty until
+	// they have been dealt.
+	Cards Hand `json:"cards,omitempty"`
+}
+
+// DealerSnapshot is the state of a Dealer.
+type DealerSnapshot struct {
+	// Hand is the dealer's full hand, including the hole card.
+	Hand Hand `json:"hand"`
+	// Deck is the dealer's whole shoe, including the cards already dealt.
+	Deck cards.Deck `json:"deck"`
+	// Index is the position of the next card to be dealt within Deck.
+	Index int `json:"index"`
+	// Hands are the dealer's hands drawn for the bets after the first of Multi-Action
+	// hands.
+	Hands []Hand `json:"hands,omitempty"`
+}
+
+// Snapshot returns the complete state of the game.
+func (g *Game) Snapshot() Snapshot {
+	s := Snapshot{
+		Rules:   g.Rules,
+		Current: -1,
+		LastID:  g.handID,
+		Dealer: DealerSnapshot{
+			Hand:  append(Hand{}, g.Dealer.hand...),
+			Deck:  append(cards.Deck{}, g.Dealer.deck...),
+			Index: g.Dealer.index,
+		},
+	}
+	for _, h := range g.Dealer.hands {
+		s.Dealer.Hands = append(s.Dealer.Hands, append(Hand{}, h...))
+	}
+
+	seats := make(map[*Player]int)
+	add := func(p *Player) {
+		if _, ok := seats[p]; !ok {
+			seats[p] = len(s.Players)
+			s.Players = append(s.Players, PlayerSnapshot{Name: p.Name, Winnings: p.Winnings})
+		}
+	}
+	for _, p := range g.seats {
+		if p == nil {
+			s.Seats = append(s.Seats, -1)
+			continue
+		}
+		add(p)
+		s.Seats = append(s.Seats, seats[p])
+	}
+	current := g.current()
+	for _, val := range g.Hands().All() {
+		add(val.Player)
+		if val == current {
+			s.Current = len(s.Hands)
+		}
+		hand := HandSnapshot{
+			ID:       val.ID,
+			Player:   seats[val.Player],
+			Hand:     append(Hand{}, val.Hand...),
+			Wager:    val.Wager,
+			Extra:    append([]int(nil), val.Extra...),
+			Free:     val.Free,
+			Split:    val.Split,
+			Doubled:  val.Doubled,
+			Second:   val.Second,
+			Switched: val.Switched,
+			Seat:     val.Seat,
+		}
+		for _, bet := range val.BackBets {
+			add(bet.Player)
+			hand.BackBets = append(hand.BackBets, BackBetSnapshot{
+				Player:       seats[bet.Player],
+				Wager:        bet.Wager,
+				FollowDouble: bet.FollowDouble,
+				FollowSplit:  bet.FollowSplit,
+			})
+		}
+		for _, w := range val.SideBets {
+			hand.SideBets = append(hand.SideBets, SideBetSnapshot{
+				SideBetResult: w.Result(),
+				Cards:         append(Hand(nil), w.cards...),
+			})
+		}
+		s.Hands = append(s.Hands, hand)
+	}
+	return s
+}
+
+// Restore returns a new Game in the state of the specified snapshot.
+func Restore(s Snapshot) (*Game, error) {
+	if s.Current < -1 || s.Current >= len(s.Hands) {
+		return nil, fmt.Errorf("blackjack: current hand %d is out of range", s.Current)
+	}
+	if s.Dealer.Index < 0 || s.Dealer.Index > len(s.Dealer.Deck) {
+		return nil, fmt.Errorf("blackjack: dealer index %d is out of range", s.Dealer.Index)
+	}
+
+	game := New()
+	game.Rules = s.Rules
+	players := make([]*Player, 0, len(s.Players))
+	for _, p := range s.Players {
+		players = append(players, &Player{Name: p.Name, Winnings: p.Winnings})
+	}
+	if len(s.Seats) > s.Rules.Seats {
+		return nil, fmt.Errorf("blackjack: %d seats are more than the table's %d", len(s.Seats), s.Rules.Seats)
+	}
+	for i, p := range s.Seats {
+		if p < -1 || p >= len(players) {
+			return nil, fmt.Errorf("blackjack: seat %d refers to unknown player %d", i+1, p)
+		}
+		var player *Player
+		if p >= 0 {
+			player = players[p]
+		}
+		game.seats = append(game.seats, player)
+	}
+
+	// Hands without an ID, such as those of a snapshot taken before hands had IDs, are
+	// given one after the highest ID of the snapshot, or after its last ID if it is higher.
+	if s.LastID < 0 {
+		return nil, fmt.Errorf("blackjack: last hand ID %d is negative", s.LastID)
+	}
+	game.handID = s.LastID
+	ids := make(map[int]bool)
+	for _, h := range s.Hands {
+		if h.ID == 0 {
+			continue
+		}
+		if ids[h.ID] {
+			return nil, fmt.Errorf("blackjack: more than one hand has ID %d", h.ID)
+		}
+		ids[h.ID] = true
+		if h.ID > game.handID {
+			game.handID = h.ID
+		}
+	}
+
+	hands := game.Hands()
+	for i, h := range s.Hands {
+		if h.Player < 0 || h.Player >= len(players) {
+			return nil, fmt.Errorf("blackjack: hand %d refers to unknown player %d", i, h.Player)
+		}
+		val := &ListVal{
+			ID:       h.ID,
+			Player:   players[h.Player],
+			Hand:     append(Hand{}, h.Hand...),
+			Wager:    h.Wager,
+			Extra:    append([]int(nil), h.Extra...),
+			Free:     h.Free,
+			Split:    h.Split,
+			Doubled:  h.Doubled,
+			Second:   h.Second,
+			Switched: h.Switched,
+			Seat:     h.Seat,
+		}
+		for _, bet := range h.BackBets {
+			if bet.Player < 0 || bet.Player >= len(players) {
+				return nil, fmt.Errorf("blackjack: back bet on hand %d refers to unknown player %d", i, bet.Player)
+			}
+			val.BackBets = append(val.BackBets, &BackBet{
+				Player:       players[bet.Player],
+				Wager:        bet.Wager,
+				FollowDouble: bet.FollowDouble,
+				FollowSplit:  bet.FollowSplit,
+			})
+		}
+		for _, w := range h.SideBets {
+			bet, ok := LookupSideBet(w.Name)
+			if !ok {
+				return nil, fmt.Errorf("blackjack: side bet on hand %d is the unknown side bet %q", i, w.Name)
+			}
+			val.SideBets = append(val.SideBets, &SideWager{
+				Bet:     bet,
+				Wager:   w.Wager,
+				Settled: w.Settled,
+				Outcome: w.Outcome,
+				Payout:  w.Payout,
+				cards:   append(Hand(nil), w.Cards...),
+			})
+		}
+		hands.Append(val)
+	}
+	if s.Current >= 0 {
+		hands.SetCurrent(hands.At(s.Current))
+	}
+
+	game.Dealer.hand = append(Hand{}, s.Dealer.Hand...)
+	game.Dealer.deck = append(cards.Deck{}, s.Dealer.Deck...)
+	game.Dealer.index = s.Dealer.Index
+	for _, h := range s.Dealer.Hands {
+		game.Dealer.hands = append(game.Dealer.hands, append(Hand{}, h...))
+	}
+	return game, nil
+}
+
+// MarshalJSON encodes the Snapshot of the game.
+func (g *Game) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Snapshot())
+}
+
+// UnmarshalJSON restores the game from an encoded Snapshot. Listeners registered on the
+// game are kept.
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	restored, err := Restore(s)
+	if err != nil {
+		return err
+	}
+	g.Rules = restored.Rules
+	g.hands = restored.hands
+	g.hands.game = g
+	g.Players = restored.Players
+	g.seats = restored.seats
+	g.handID = restored.handID
+	g.Current = restored.Current
+	g.Dealer = restored.Dealer
+	g.Dealer.Game = g
+	return nil
+}
@@ -0,0 +1,189 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestGameSnapshotRestore(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	a.Winnings = 10
+	game.AddPlayer(a)
+	game.AddPlayer(b)
+
+	game.Dealer.UseDecks(1)
+	game.Dealer.Shuffle(3)
+	for curr := game.Players; curr != nil; curr = curr.Tail {
+		game.Dealer.Bet(curr.Head, 2)
+	}
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	aceOfSpades := cards.Card{Rank: cards.Ace, Suit: cards.Spades}
+	aceOfHearts := cards.Card{Rank: cards.Ace, Suit: cards.Hearts}
+	game.Players.Head.Hand = Hand{aceOfSpades, aceOfHearts}
+	game.Dealer.Split()
+	game.Dealer.Stay()
+
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatalf("could not marshal game: %v", err)
+	}
+
+	restored := New()
+	var events int
+	restored.Listen(func(Event) { events++ })
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("could not unmarshal game: %v", err)
+	}
+
+	if restored.Players.Len() != 3 {
+		t.Fatalf("expected the restored game to have 3 hands but has %d", restored.Players.Len())
+	}
+	if !restored.Players.Tail.Head.Split {
+		t.Fatalf("expected the second hand of the restored game to be a split hand")
+	}
+	if restored.Current != restored.Players.Tail {
+		t.Fatalf("expected the restored game to be waiting on the second hand")
+	}
+	if restored.Players.Head.Player != restored.Players.Tail.Head.Player {
+		t.Fatalf("expected both split hands to belong to the same restored player")
+	}
+	if restored.Players.Head.Player.Winnings != 10 {
+		t.Fatalf("expected the restored player a to have 10 winnings but has %d", restored.Players.Head.Player.Winnings)
+	}
+	if restored.Dealer.Game != restored {
+		t.Fatalf("expected the restored dealer to refer to the restored game")
+	}
+
+	for _, g := range []*Game{game, restored} {
+		g.Dealer.Stay()
+		g.Dealer.Stay()
+		g.Dealer.Play()
+		g.Dealer.Collect()
+	}
+	if events == 0 {
+		t.Fatalf("expected listeners of the restored game to be kept")
+	}
+
+	if game.Dealer.hand.Value() != restored.Dealer.hand.Value() {
+		t.Fatalf("expected the restored dealer to draw the same cards as the original")
+	}
+	want, have := game.Snapshot(), restored.Snapshot()
+	for i := range want.Players {
+		if want.Players[i] != have.Players[i] {
+			t.Fatalf("expected restored player %v to match %v", have.Players[i], want.Players[i])
+		}
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	if _, err := Restore(Snapshot{Current: 2}); err == nil {
+		t.Fatalf("expected a snapshot with an out of range current hand to be invalid")
+	}
+	if _, err := Restore(Snapshot{Current: -1, Hands: []HandSnapshot{{Player: 1}}}); err == nil {
+		t.Fatalf("expected a snapshot with a hand of an unknown player to be invalid")
+	}
+	if _, err := Restore(Snapshot{Current: -1, Players: []PlayerSnapshot{{Name: "a"}}, Seats: []int{-1, 0}}); err == nil {
+		t.Fatalf("expected a snapshot with a seat the table does not have to be invalid")
+	}
+}
+
+func TestGameSnapshotSeats(t *testing.T) {
+	game := New()
+	game.Rules.Seats = 3
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.Sit(b, 3)
+	game.Sit(a, 1)
+	game.RemovePlayer(a)
+
+	restored, err := Restore(game.Snapshot())
+	if err != nil {
+		t.Fatalf("expected the snapshot to be restored but got %v", err)
+	}
+	if restored.SeatedAt(1).Name != "a" || restored.SeatedAt(3).Name != "b" {
+		t.Fatalf("expected the seats to be restored, including a player without a hand")
+	}
+	if restored.Players.Len() != 1 || restored.Players.Head.Player != restored.SeatedAt(3) {
+		t.Fatalf("expected the hand of b to belong to the seated b")
+	}
+}
+
+func TestGameSnapshotBackBets(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	game.Dealer.BackBet(game.Players.Head, &BackBet{Player: b, Wager: 5, FollowSplit: true})
+
+	restored, err := Restore(game.Snapshot())
+	if err != nil {
+		t.Fatalf("expected the snapshot to be restored but got %v", err)
+	}
+	bets := restored.Players.Head.BackBets
+	if len(bets) != 1 || bets[0].Player.Name != "b" || bets[0].Wager != 5 || !bets[0].FollowSplit {
+		t.Fatalf("expected the back bet of b to be restored")
+	}
+}
+
+func TestGameSnapshotHandIDs(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	game.AddPlayer(NewPlayer("b"))
+	game.RemoveListVal(game.Players.Head)
+
+	snapshot := game.Snapshot()
+	restored, err := Restore(snapshot)
+	if err != nil {
+		t.Fatalf("expected the snapshot to be restored but got %v", err)
+	}
+	if restored.Players.Head.ID != 2 {
+		t.Fatalf("expected the ID of the hand to be restored but was %d", restored.Players.Head.ID)
+	}
+	restored.AddPlayer(NewPlayer("c"))
+	if restored.Players.Tail.Head.ID != 3 {
+		t.Fatalf("expected a hand added after restoring to have a new ID")
+	}
+
+	snapshot.Hands[0].ID = 0
+	restored, _ = Restore(snapshot)
+	if restored.Players.Head.ID == 0 {
+		t.Fatalf("expected a hand without an ID to be given one")
+	}
+}
+
+func TestGameSnapshotRemovedHandIDs(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Eight}, {Rank: cards.Five}, {Rank: cards.Eight}, {Rank: cards.Six},
+		{Rank: cards.Two}, {Rank: cards.Three},
+	})
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+	game.Dealer.Split()
+	if split := game.Hands().At(1); split == nil || split.ID != 2 {
+		t.Fatalf("expected the split hand to have ID 2")
+	}
+	game.Dealer.ResetTable()
+
+	snapshot := game.Snapshot()
+	restored, err := Restore(snapshot)
+	if err != nil {
+		t.Fatalf("expected the snapshot to be restored but got %v", err)
+	}
+	restored.AddPlayer(NewPlayer("b"))
+	if id := restored.Players.Tail.Head.ID; id != 3 {
+		t.Fatalf("expected the ID of the removed split hand not to be reused but got %d", id)
+	}
+
+	// snapshots taken before the last ID was kept fall back on the highest ID
+	snapshot.LastID = 0
+	restored, _ = Restore(snapshot)
+	restored.AddPlayer(NewPlayer("b"))
+	if id := restored.Players.Tail.Head.ID; id != 2 {
+		t.Fatalf("expected a hand added after restoring to follow the highest ID but got %d", id)
+	}
+}
@@ -0,0 +1,121 @@
+package blackjack
+
+import (
+	"github.com/ethanefung/cards"
+)
+
+// spanish21 is the behaviour of Spanish21.
+type spanish21 struct {
+	standard
+}
+
+// deck returns a deck of 48 cards without the tens. The jacks, queens and kings are kept.
+func (spanish21) deck() cards.Deck {
+	deck := cards.New()
+	deck.Omit(func(i int) bool {
+		return deck[i].Rank == cards.Ten
+	})
+	return deck
+}
+
+// outcome wins every 21 of the player unless the dealer has a natural, which only loses
+// to a natural of the player.
+func (s spanish21) outcome(val *ListVal, dealer Hand) WinType {
+	switch {
+	case val.natural():
+		return Win
+	case dealer.natural():
+		return Lose
+	case val.Hand.Value() == 21:
+		return Win
+	}
+	return s.standard.outcome(val, dealer)
+}
+
+// payout pays 3 to 2 for a natural, and bonuses for 21s that were not doubled: 3 to 2
+// for five cards, 2 to 1 for six cards and 3 to 1 for seven or more cards, and for 6-7-8
+// or 7-7-7 3 to 2 in mixed suits, 2 to 1 suited and 3 to 1 in spades.
+func (s spanish21) payout(val *ListVal, t WinType, wager int) int {
+	if t != Win {
+		return s.standard.payout(val, t, wager)
+	}
+	h := val.Hand
+	switch {
+	case val.natural():
+		return wager * 3 / 2
+	case val.Doubled || h.Value() != 21:
+		return wager
+	case h.isSevens() || h.isSixSevenEight():
+		if !h.suited() {
+			return wager * 3 / 2
+		}
+		if h[0].Suit == cards.Spades {
+			return wager * 3
+		}
+		return wager * 2
+	case len(h) >= 7:
+		return wager * 3
+	case len(h) == 6:
+		return wager * 2
+	case len(h) == 5:
+		return wager * 3 / 2
+	}
+	return wager
+}
+
+// allows lets a hand double on any number of cards, but only once, after which the hand
+// may only stay or be rescued by surrendering it. A hand may otherwise only surrender on
+// its first two cards when the dealer does not have a natural.
+func (s spanish21) allows(g *Game, val *ListVal, a Action) bool {
+	switch a {
+	case Stay:
+		return true
+	case Surrender:
+		if val.Doubled {
+			return true
+		}
+		return len(val.Hand) == 2 && !val.Split && !g.Dealer.hand.natural()
+	case Double:
+		return !val.Doubled
+	}
+	return !val.Doubled && s.standard.allows(g, val, a)
+}
+
+func (spanish21) rescues() bool {
+	return true
+}
+
+// isSevens returns true if the hand is three sevens.
+func (h *Hand) isSevens() bool {
+	if len(*h) != 3 {
+		return false
+	}
+	for _, c := range *h {
+		if c.Rank != cards.Seven {
+			return false
+		}
+	}
+	return true
+}
+
+// isSixSevenEight returns true if the hand is a six, a seven and an eight in any order.
+func (h *Hand) isSixSevenEight() bool {
+	if len(*h) != 3 {
+		return false
+	}
+	ranks := make(map[cards.Rank]bool)
+	for _, c := range *h {
+		ranks[c.Rank] = true
+	}
+	return ranks[cards.Six] && ranks[cards.Seven] && ranks[cards.Eight]
+}
+
+// suited returns true if every card of the hand has the same suit.
+func (h *Hand) suited() bool {
+	for _, c := range *h {
+		if c.Suit != (*h)[0].Suit {
+			return false
+		}
+	}
+	return true
+}
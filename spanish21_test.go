@@ -0,0 +1,109 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestSpanish21Deck(t *testing.T) {
+	game := New()
+	game.Rules = Rules{Decks: 2, Variant: Spanish21}
+	game.Dealer.UseRules()
+
+	if game.Dealer.Remaining() != 96 {
+		t.Fatalf("expected a shoe of two 48 card decks but had %d cards", game.Dealer.Remaining())
+	}
+	for _, c := range game.Dealer.Shoe() {
+		if c.Rank == cards.Ten {
+			t.Fatalf("expected the shoe to have no tens but found %v", c)
+		}
+	}
+}
+
+func TestSpanish21Payouts(t *testing.T) {
+	card := func(r cards.Rank, s cards.Suit) cards.Card {
+		return cards.Card{Rank: r, Suit: s}
+	}
+	natural := Hand{card(cards.Ace, cards.Clubs), card(cards.King, cards.Clubs)}
+	nineteen := Hand{card(cards.Nine, cards.Clubs), card(cards.King, cards.Clubs)}
+	tests := []struct {
+		val     ListVal
+		dealer  Hand
+		outcome WinType
+		payout  int
+	}{
+		{ListVal{Hand: natural}, nineteen, Win, 15},
+		{ListVal{Hand: natural}, natural, Win, 15},
+		{ListVal{Hand: natural, Split: true}, natural, Lose, -10},
+		{ListVal{Hand: Hand{card(cards.Nine, cards.Clubs), card(cards.Two, cards.Clubs), card(cards.Queen, cards.Clubs)}}, Hand{card(cards.Ace, cards.Clubs), card(cards.Nine, cards.Clubs), card(cards.Ace, cards.Clubs)}, Win, 10},
+		{ListVal{Hand: Hand{card(cards.Nine, cards.Clubs), card(cards.Two, cards.Clubs), card(cards.Queen, cards.Clubs)}, Doubled: true}, nineteen, Win, 10},
+		{ListVal{Hand: Hand{card(cards.Two, cards.Clubs), card(cards.Three, cards.Clubs), card(cards.Four, cards.Clubs), card(cards.Five, cards.Clubs), card(cards.Seven, cards.Hearts)}}, nineteen, Win, 15},
+		{ListVal{Hand: Hand{card(cards.Two, cards.Clubs), card(cards.Three, cards.Clubs), card(cards.Four, cards.Clubs), card(cards.Five, cards.Clubs), card(cards.Two, cards.Hearts), card(cards.Five, cards.Hearts)}}, nineteen, Win, 20},
+		{ListVal{Hand: Hand{card(cards.Two, cards.Clubs), card(cards.Three, cards.Clubs), card(cards.Four, cards.Clubs), card(cards.Two, cards.Hearts), card(cards.Three, cards.Hearts), card(cards.Four, cards.Hearts), card(cards.Three, cards.Spades)}}, nineteen, Win, 30},
+		{ListVal{Hand: Hand{card(cards.Six, cards.Clubs), card(cards.Eight, cards.Hearts), card(cards.Seven, cards.Clubs)}}, nineteen, Win, 15},
+		{ListVal{Hand: Hand{card(cards.Seven, cards.Hearts), card(cards.Seven, cards.Hearts), card(cards.Seven, cards.Hearts)}}, nineteen, Win, 20},
+		{ListVal{Hand: Hand{card(cards.Seven, cards.Spades), card(cards.Eight, cards.Spades), card(cards.Six, cards.Spades)}}, nineteen, Win, 30},
+		{ListVal{Hand: nineteen}, nineteen, Push, 0},
+		{ListVal{Hand: Hand{card(cards.Nine, cards.Clubs), card(cards.Jack, cards.Clubs)}}, natural, Lose, -10},
+	}
+
+	v := Rules{Variant: Spanish21}.variant()
+	for _, test := range tests {
+		outcome := v.outcome(&test.val, test.dealer)
+		payout := v.payout(&test.val, outcome, 10)
+		if outcome != test.outcome || payout != test.payout {
+			t.Errorf("expected %v against %v to %s paying %d but was %s paying %d", test.val.Hand, test.dealer, test.outcome, test.payout, outcome, payout)
+		}
+	}
+}
+
+func TestSpanish21DoubleRescue(t *testing.T) {
+	game := New()
+	game.Rules.Variant = Spanish21
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.Nine}, {Rank: cards.Three}, {Rank: cards.Eight},
+		{Rank: cards.Four}, {Rank: cards.Five},
+	})
+	val := game.Players.Head
+	game.Dealer.Bet(val, 10)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if !game.Dealer.Hit() || !game.Dealer.Double() {
+		t.Fatalf("expected the dealer to let a hand of three cards double")
+	}
+	if val.Wager != 20 || len(val.Hand) != 4 || game.PlayersPlayed() {
+		t.Fatalf("expected the doubled hand to stay in play to be rescued")
+	}
+	if game.Dealer.Hit() || game.Dealer.Double() {
+		t.Fatalf("expected the dealer to refuse to hit or double a doubled hand")
+	}
+	if !game.Dealer.Surrender() || a.Winnings != -10 || !game.PlayersPlayed() {
+		t.Fatalf("expected rescuing the doubled hand to forfeit the original wager but winnings were %d", a.Winnings)
+	}
+}
+
+func TestSpanish21LateSurrender(t *testing.T) {
+	game := New()
+	game.Rules.Variant = Spanish21
+	game.AddPlayer(NewPlayer("a"))
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two}, {Rank: cards.Ace}, {Rank: cards.Three}, {Rank: cards.King},
+	})
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if game.Dealer.Surrender() {
+		t.Fatalf("expected the dealer to refuse a surrender against a natural")
+	}
+
+	game.Dealer.hand = Hand{{Rank: cards.Nine}, {Rank: cards.King}}
+	game.Dealer.Stack(cards.Deck{{Rank: cards.Four}})
+	game.Dealer.Hit()
+	if game.Dealer.Surrender() {
+		t.Fatalf("expected the dealer to refuse a surrender after hitting")
+	}
+}
@@ -0,0 +1,139 @@
+package blackjack
+
+import (
+	"github.com/ethanefung/cards"
+)
+
+// BasicStrategy returns the action basic strategy recommends for the hand against the
+// dealer's upcard, for a shoe game where the dealer hits soft 17 and doubling after a
+// split is allowed. Double and Split are recommended even when the hand can no longer
+// double or split; Dealer.Suggest accounts for what is allowed.
+func BasicStrategy(h Hand, upcard cards.Card) Action {
+	up := cardValues[upcard.Rank]
+
+	if len(h) == 2 && h[0].Rank == h[1].Rank {
+		switch cardValues[h[0].Rank] {
+		case 11, 8:
+			return Split
+		case 9:
+			if up == 7 || up >= 10 {
+				return Stay
+			}
+			return Split
+		case 7, 3, 2:
+			if up <= 7 {
+				return Split
+			}
+			return Hit
+		case 6:
+			if up <= 6 {
+				return Split
+			}
+			return Hit
+		case 4:
+			if up == 5 || up == 6 {
+				return Split
+			}
+			return Hit
+		}
+	}
+	return totalStrategy(h, up)
+}
+
+// totalStrategy returns the action basic strategy recommends for the soft or hard total
+// of the hand against the value of the dealer's upcard, as if the hand were not a pair.
+func totalStrategy(h Hand, up int) Action {
+	val := h.Value()
+	if h.IsSoft() {
+		switch {
+		case val >= 20:
+			return Stay
+		case val == 19:
+			if up == 6 {
+				return Double
+			}
+			return Stay
+		case val == 18:
+			if up <= 6 {
+				return Double
+			}
+			if up <= 8 {
+				return Stay
+			}
+			return Hit
+		case val == 17:
+			if up >= 3 && up <= 6 {
+				return Double
+			}
+			return Hit
+		case val >= 15:
+			if up >= 4 && up <= 6 {
+				return Double
+			}
+			return Hit
+		default:
+			if up == 5 || up == 6 {
+				return Double
+			}
+			return Hit
+		}
+	}
+
+	switch {
+	case val >= 17:
+		return Stay
+	case val >= 13:
+		if up <= 6 {
+			return Stay
+		}
+		return Hit
+	case val == 12:
+		if up >= 4 && up <= 6 {
+			return Stay
+		}
+		return Hit
+	case val == 11:
+		return Double
+	case val == 10:
+		if up <= 9 {
+			return Double
+		}
+		return Hit
+	case val == 9:
+		if up >= 3 && up <= 6 {
+			return Double
+		}
+		return Hit
+	}
+	return Hit
+}
+
+// Suggest returns the action basic strategy recommends for the current player, limited
+// to the actions the player is allowed to take. A pair that may not be split is played
+// by its total. Suggest returns Stay if there is no current player. When none of the
+// dealer's cards are face up, Suggest hits below 17.
+func (d *Dealer) Suggest() Action {
+	if d.Game.current() == nil || len(d.hand) < 2 {
+		return Stay
+	}
+	h := d.Game.current().Hand
+	shown := d.ShowHand()
+	if len(shown) == 0 {
+		if h.Value() < 17 && d.allows(d.Game.current(), Hit) {
+			return Hit
+		}
+		return Stay
+	}
+	upcard := shown[len(shown)-1]
+	a := BasicStrategy(h, upcard)
+	if a == Split && !d.allows(d.Game.current(), Split) {
+		a = totalStrategy(h, cardValues[upcard.Rank])
+	}
+	if a == Double && !d.allows(d.Game.current(), Double) {
+		if h.IsSoft() && h.Value() >= 18 {
+			return Stay
+		}
+		return Hit
+	}
+	return a
+}
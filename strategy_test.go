@@ -0,0 +1,71 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestBasicStrategy(t *testing.T) {
+	ten := cards.Card{Rank: cards.King}
+	six := cards.Card{Rank: cards.Six}
+
+	tests := []struct {
+		hand   Hand
+		upcard cards.Card
+		want   Action
+	}{
+		{Hand{{Rank: cards.Ace}, {Rank: cards.Ace}}, ten, Split},
+		{Hand{{Rank: cards.Eight}, {Rank: cards.Eight}}, ten, Split},
+		{Hand{{Rank: cards.Ten}, {Rank: cards.Ten}}, six, Stay},
+		{Hand{{Rank: cards.Nine}, {Rank: cards.Nine}}, cards.Card{Rank: cards.Seven}, Stay},
+		{Hand{{Rank: cards.Five}, {Rank: cards.Five}}, six, Double},
+		{Hand{{Rank: cards.Ace}, {Rank: cards.Seven}}, six, Double},
+		{Hand{{Rank: cards.Ace}, {Rank: cards.Seven}}, ten, Hit},
+		{Hand{{Rank: cards.Ace}, {Rank: cards.Eight}}, ten, Stay},
+		{Hand{{Rank: cards.Ten}, {Rank: cards.Six}}, six, Stay},
+		{Hand{{Rank: cards.Ten}, {Rank: cards.Six}}, ten, Hit},
+		{Hand{{Rank: cards.Ten}, {Rank: cards.Two}}, cards.Card{Rank: cards.Three}, Hit},
+		{Hand{{Rank: cards.Six}, {Rank: cards.Five}}, cards.Card{Rank: cards.Ace}, Double},
+		{Hand{{Rank: cards.Ten}, {Rank: cards.Seven}}, cards.Card{Rank: cards.Ace}, Stay},
+	}
+	for _, test := range tests {
+		if have := BasicStrategy(test.hand, test.upcard); have != test.want {
+			t.Fatalf("expected basic strategy to %s with %v against %v but was %s", test.want, test.hand, test.upcard, have)
+		}
+	}
+}
+
+func TestDealerSuggest(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+
+	if game.Dealer.Suggest() != Stay {
+		t.Fatalf("expected the dealer to suggest staying when there is no current player")
+	}
+
+	game.Dealer.hand = Hand{{Rank: cards.Ten}, {Rank: cards.Six}}
+	game.Start()
+	game.Current.Head.Hand = Hand{{Rank: cards.Five}, {Rank: cards.Six}}
+	if game.Dealer.Suggest() != Double {
+		t.Fatalf("expected the dealer to suggest doubling 11 against a six")
+	}
+
+	game.Current.Head.Hand = Hand{{Rank: cards.Two}, {Rank: cards.Four}, {Rank: cards.Five}}
+	if game.Dealer.Suggest() != Hit {
+		t.Fatalf("expected the dealer to suggest hitting 11 when the hand can no longer double")
+	}
+
+	game.Current.Head.Hand = Hand{{Rank: cards.Ace}, {Rank: cards.Four}, {Rank: cards.Three}}
+	if game.Dealer.Suggest() != Stay {
+		t.Fatalf("expected the dealer to suggest staying on soft 18 when the hand can no longer double")
+	}
+
+	// a doubled hand of Spanish 21 may not be split
+	game.Rules.Variant = Spanish21
+	game.Current.Head.Hand = Hand{{Rank: cards.Eight}, {Rank: cards.Eight}}
+	game.Current.Head.Doubled = true
+	if game.Dealer.Suggest() != Stay {
+		t.Fatalf("expected the dealer to suggest staying on 16 against a six when the eights may not be split")
+	}
+}
@@ -0,0 +1,96 @@
+package blackjack
+
+// blackjackSwitch is the behaviour of BlackjackSwitch.
+type blackjackSwitch struct {
+	standard
+}
+
+// prepare adds a second hand with the same wager after every hand of a seat that does
+// not have one.
+func (blackjackSwitch) prepare(g *Game) {
+	hands := g.Hands()
+	for _, val := range hands.All() {
+		if val.Split || val.Second || secondHand(g, val) != nil {
+			continue
+		}
+		hands.InsertAfter(val, &ListVal{
+			Player: val.Player,
+			Wager:  val.Wager,
+			Seat:   val.Seat,
+			Second: true,
+		})
+	}
+}
+
+// hands deals two hands to every seat.
+func (blackjackSwitch) hands() int {
+	return 2
+}
+
+// outcome pushes every hand against a dealer's 22. Otherwise a natural beats any other
+// 21, and naturals push. A 21 made by switching is not a natural.
+func (s blackjackSwitch) outcome(val *ListVal, dealer Hand) WinType {
+	switch {
+	case dealer.Value() == 22:
+		return Push
+	case val.natural() && dealer.natural():
+		return Push
+	case val.natural():
+		return Win
+	case dealer.natural():
+		return Lose
+	}
+	return s.standard.outcome(val, dealer)
+}
+
+// allows lets the first hand of a seat switch before either of the seat's hands has been
+// played or split.
+func (s blackjackSwitch) allows(g *Game, val *ListVal, a Action) bool {
+	if a != Switch {
+		return s.standard.allows(g, val, a)
+	}
+	// the hands split from the first hand are played between it and the second hand
+	hands := g.Hands()
+	second := secondHand(g, val)
+	return second != nil && hands.At(hands.Index(val)+1) == second &&
+		len(val.Hand) == 2 && len(second.Hand) == 2
+}
+
+// secondHand returns the second hand of the seat the specified hand was dealt to, or nil
+// if the hand is not the first hand of a seat or has no second hand.
+func secondHand(g *Game, val *ListVal) *ListVal {
+	if val.Split || val.Second {
+		return nil
+	}
+	hands := g.Hands()
+	for i := hands.Index(val) + 1; i < hands.Len(); i++ {
+		next := hands.At(i)
+		if !next.Split && !next.Second {
+			return nil
+		}
+		if next.Second && next.Player == val.Player && next.Seat == val.Seat {
+			return next
+		}
+	}
+	return nil
+}
+
+// Switch swaps the second cards of the current hand and the second hand of its seat, in
+// Blackjack Switch. Switching again swaps the cards back, and a 21 made by switching is
+// not a natural. Switch returns false if the game is not Blackjack Switch, the current
+// hand is not the first hand of its seat, or either hand has been played or split.
+func (d *Dealer) Switch() bool {
+	val := d.Game.current()
+	if !d.allows(val, Switch) {
+		return false
+	}
+	second := secondHand(d.Game, val)
+	if second == nil {
+		return false
+	}
+	val.Hand[1], second.Hand[1] = second.Hand[1], val.Hand[1]
+	val.Switched = !val.Switched
+	second.Switched = !second.Switched
+	d.Game.emit(ActionTaken{Hand: val, Action: Switch})
+	return true
+}
@@ -0,0 +1,120 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestBlackjackSwitch(t *testing.T) {
+	game := New()
+	game.Rules.Variant = BlackjackSwitch
+	recorder := NewRecorder(game)
+	a := NewPlayer("a")
+	game.AddPlayer(a)
+	game.Dealer.Bet(game.Players.Head, 10)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.King}, {Rank: cards.Five}, {Rank: cards.Nine},
+		{Rank: cards.Six}, {Rank: cards.Ace}, {Rank: cards.Seven},
+		{Rank: cards.Five},
+	})
+	game.Dealer.Deal(2, game.Players)
+
+	hands := game.Hands()
+	first, second := hands.At(0), hands.At(1)
+	if hands.Len() != 2 || !second.Second || second.Wager != 10 {
+		t.Fatalf("expected the seat to be dealt a second hand with the same wager")
+	}
+
+	game.Start()
+	if !game.Dealer.Switch() || first.Hand.Value() != 21 || second.Hand.Value() != 11 {
+		t.Fatalf("expected the second cards of the hands to be switched but were %v and %v", first.Hand, second.Hand)
+	}
+	if !first.Switched || !second.Switched || first.natural() {
+		t.Fatalf("expected the switched 21 not to be a natural")
+	}
+	game.Dealer.Stay()
+	if game.Dealer.Switch() {
+		t.Fatalf("expected the dealer to refuse to switch from the second hand")
+	}
+	game.Dealer.Stay()
+	game.Dealer.Play()
+	game.Dealer.Collect()
+
+	// the switched 21 pushes the dealer's 21 and the 11 loses
+	if a.Winnings != -10 {
+		t.Fatalf("expected the switched 21 to push and the 11 to lose but winnings were %d", a.Winnings)
+	}
+
+	replayed, err := recorder.History().Rounds[0].Replay(2)
+	if err != nil || replayed.Hands().Len() != 2 || !replayed.Hands().At(0).Switched {
+		t.Fatalf("expected the round to be replayed with both hands of the seat switched but got %v", err)
+	}
+
+	game.Dealer.Clear()
+	game.Dealer.ResetTable()
+	if game.Hands().Len() != 1 || first.Switched {
+		t.Fatalf("expected the second hand to be removed once the round is over")
+	}
+}
+
+func TestBlackjackSwitchAfterSplit(t *testing.T) {
+	game := New()
+	game.Rules.Variant = BlackjackSwitch
+	game.AddPlayer(NewPlayer("a"))
+	game.Dealer.Bet(game.Players.Head, 10)
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Eight}, {Rank: cards.Five}, {Rank: cards.Nine},
+		{Rank: cards.Eight}, {Rank: cards.Six}, {Rank: cards.Seven},
+		{Rank: cards.Three}, {Rank: cards.Four}, {Rank: cards.Ten},
+	})
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if !game.Dealer.Split() {
+		t.Fatalf("expected the pair of eights to be split")
+	}
+	if game.Dealer.Switch() {
+		t.Fatalf("expected the dealer to refuse to switch a hand that was split")
+	}
+}
+
+func TestBlackjackSwitchOutcomes(t *testing.T) {
+	card := func(r cards.Rank) cards.Card {
+		return cards.Card{Rank: r, Suit: cards.Clubs}
+	}
+	natural := Hand{card(cards.Ace), card(cards.King)}
+	twentyOne := Hand{card(cards.Five), card(cards.Six), card(cards.King)}
+	tests := []struct {
+		hand    Hand
+		dealer  Hand
+		outcome WinType
+	}{
+		{Hand{card(cards.King), card(cards.Queen)}, Hand{card(cards.Six), card(cards.Six), card(cards.King)}, Push},
+		{Hand{card(cards.King), card(cards.Queen)}, Hand{card(cards.Six), card(cards.Seven), card(cards.King)}, Win},
+		{natural, twentyOne, Win},
+		{twentyOne, natural, Lose},
+		{natural, natural, Push},
+		{Hand{card(cards.King), card(cards.Nine)}, Hand{card(cards.King), card(cards.Queen)}, Lose},
+	}
+
+	v := Rules{Variant: BlackjackSwitch}.variant()
+	for _, test := range tests {
+		if outcome := v.outcome(&ListVal{Hand: test.hand}, test.dealer); outcome != test.outcome {
+			t.Errorf("expected %v against %v to %s but was %s", test.hand, test.dealer, test.outcome, outcome)
+		}
+	}
+}
+
+func TestDealerSwitchStandard(t *testing.T) {
+	game := New()
+	game.AddPlayer(NewPlayer("a"))
+	game.AddPlayer(NewPlayer("b"))
+	game.Dealer.UseDecks(1)
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	if game.Dealer.Act(Switch) {
+		t.Fatalf("expected the dealer to refuse to switch outside of Blackjack Switch")
+	}
+}
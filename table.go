@@ -0,0 +1,747 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidPlayer is returned when a player has no name.
+	ErrInvalidPlayer = errors.New("blackjack: invalid player")
+	// ErrAlreadySeated is returned when a player joins a table they are seated at.
+	ErrAlreadySeated = errors.New("blackjack: player is already seated")
+	// ErrPlayerNotFound is returned when a player is not seated at the table.
+	ErrPlayerNotFound = errors.New("blackjack: player is not seated")
+	// ErrRoundInProgress is returned when the table may only change between rounds.
+	ErrRoundInProgress = errors.New("blackjack: round in progress")
+	// ErrRoundNotStarted is returned when a player acts while the table waits on bets.
+	ErrRoundNotStarted = errors.New("blackjack: round not started")
+	// ErrNotYourTurn is returned when a player acts out of turn.
+	ErrNotYourTurn = errors.New("blackjack: not your turn")
+	// ErrInvalidBet is returned when a wager is not allowed at the table.
+	ErrInvalidBet = errors.New("blackjack: invalid bet")
+	// ErrIllegalAction is returned when the dealer refuses an action.
+	ErrIllegalAction = errors.New("blackjack: illegal action")
+	// ErrTableClosed is returned when a player joins or bets at a closed table.
+	ErrTableClosed = errors.New("blackjack: table closed")
+	// ErrInvalidSeat is returned when a player sits at a seat the table does not have.
+	ErrInvalidSeat = errors.New("blackjack: invalid seat")
+	// ErrSeatTaken is returned when a player sits at a seat taken by another player.
+	ErrSeatTaken = errors.New("blackjack: seat taken")
+	// ErrTableFull is returned when a player joins a table without an empty seat.
+	ErrTableFull = errors.New("blackjack: table full")
+	// ErrSpotLimit is returned when a player sits at more seats than the rules allow.
+	ErrSpotLimit = errors.New("blackjack: spot limit reached")
+)
+
+// AutoAction is the action taken on behalf of a player whose turn has timed out.
+type AutoAction int
+
+const (
+	// AutoStay stays on the player's hand.
+	AutoStay AutoAction = iota
+	// AutoBasicStrategy plays the player's hand by basic strategy.
+	AutoBasicStrategy
+)
+
+// Timeouts are the deadlines a table holds its players to, so that an idle player
+// cannot block the table. A zero duration waits forever.
+type Timeouts struct {
+	// Turn is how long a player has to act on their hand.
+	Turn time.Duration `json:"turn"`
+	// Betting is how long players have to bet once the first bet of a round is placed.
+	// Players who have not bet by then are not dealt in.
+	Betting time.Duration `json:"betting"`
+	// Auto is the action taken when a player's turn times out.
+	Auto AutoAction `json:"auto"`
+	// SitOut is the number of timeouts in a row after which a player sits out until
+	// they bet again, zero to never sit players out.
+	SitOut int `json:"sitOut"`
+}
+
+// TableConfig configures a Table.
+type TableConfig struct {
+	// Rules are the rules the table is played with.
+	Rules Rules
+	// Timeouts are the deadlines of the table.
+	Timeouts Timeouts
+	// Clock schedules the timeouts of the table. Clock defaults to SystemClock.
+	Clock Clock
+	// Seed returns the seed used to shuffle the shoe. Seed defaults to the current time.
+	Seed func() int64
+}
+
+// Table runs rounds of a Game for players who join, bet and act concurrently. Every
+// method of a Table may be called from any goroutine; the table serializes them so that
+// only one changes the game at a time.
+//
+// A round is dealt once every seated player who is not sitting out has bet, and the
+// dealer plays and settles the round once every hand has been played. Players may only
+// join and leave between rounds. When the rules of the table have seats, players are
+// dealt from first base to third base.
+type Table struct {
+	mu      sync.Mutex
+	game    *Game
+	seed    func() int64
+	players []*Player
+	bets    map[spot]int
+	playing bool
+	results []Settlement
+
+	seq         int
+	turn        *ListVal
+	subscribers map[int]func(TableEvent)
+	nextSub     int
+
+	clock      Clock
+	timeouts   Timeouts
+	strikes    map[*Player]int
+	sittingOut map[*Player]bool
+	turnTimer  Timer
+	turnGen    int
+	betTimer   Timer
+	betGen     int
+	closed     bool
+}
+
+// TableState is a read-only copy of the state of a table as seen by a single player.
+type TableState struct {
+	// Seq is the sequence number of the last event of the table.
+	Seq int `json:"seq"`
+	// Rules are the rules the table is played with.
+	Rules Rules `json:"rules"`
+	// Playing is true while a round is being played, and false while the table is
+	// waiting on bets.
+	Playing bool `json:"playing"`
+	// Turn is the name of the player whose turn it is.
+	Turn string `json:"turn,omitempty"`
+	// Seats are the spots played at the table in the order they are dealt.
+	Seats []Seat `json:"seats"`
+	// View is the game as seen by the player.
+	View View `json:"view"`
+	// Results are the settlements of the last round.
+	Results []Settlement `json:"results,omitempty"`
+}
+
+// spot is a seat played by a player, seat zero at a table without seats.
+type spot struct {
+	player *Player
+	seat   int
+}
+
+// Seat is a spot played by a player at a table.
+type Seat struct {
+	// Number is the number of the seat, zero if the table has no seats.
+	Number int `json:"number,omitempty"`
+	// Player is the name of the player.
+	Player string `json:"player"`
+	// Winnings are the winnings of the player.
+	Winnings int `json:"winnings"`
+	// Bet is the wager the player has placed on the seat for the next round, zero if the
+	// player has yet to bet.
+	Bet int `json:"bet"`
+	// SittingOut is true when the player has timed out too many times in a row and is
+	// not dealt in until they bet again.
+	SittingOut bool `json:"sittingOut"`
+}
+
+// Settlement is the settlement of a hand at a table.
+type Settlement struct {
+	// Hand is the ID of the hand.
+	Hand int `json:"hand"`
+	// Seat is the seat the hand was played from, zero if the table has no seats.
+	Seat int `json:"seat,omitempty"`
+	// Player is the name of the hand's owner.
+	Player string `json:"player"`
+	// State is the WinState of the hand.
+	State WinState `json:"state"`
+	// Payout is the change to the player's winnings.
+	Payout int `json:"payout"`
+	// Bet is the position of the settled bet among the bets of a Multi-Action hand, zero
+	// for the hand's wager.
+	Bet int `json:"bet,omitempty"`
+}
+
+// TableEvent is an Event of a table along with its sequence number and the position of
+// the hand it is about.
+type TableEvent struct {
+	// Seq is the sequence number of the event within the table.
+	Seq int
+	// Hand is the position of the hand the event is about within Game.Players, or -1 if
+	// the event is not about a player's hand.
+	Hand int
+	// HandID is the ID of the hand the event is about, or 0 if the event is not about a
+	// player's hand.
+	HandID int
+	// Event is the event. Events refer to the table's hands, so they must not be kept
+	// after the subscriber returns.
+	Event Event
+}
+
+// PlayerSeated is emitted when a player joins a table.
+type PlayerSeated struct {
+	// Player is the name of the player.
+	Player string
+	// Seat is the seat of the player, zero if the table has no seats.
+	Seat int
+}
+
+// PlayerLeft is emitted when a player leaves a table.
+type PlayerLeft struct {
+	// Player is the name of the player.
+	Player string
+}
+
+// TurnStarted is emitted when the player whose turn it is changes.
+type TurnStarted struct {
+	// Player is the name of the player whose turn it is, empty when no hand is being
+	// played.
+	Player string
+	// Deadline is when the turn times out, zero if the table has no turn timeout.
+	Deadline time.Time
+}
+
+// TurnTimedOut is emitted when a player fails to bet or act in time.
+type TurnTimedOut struct {
+	// Player is the name of the player.
+	Player string
+	// Action is the action taken on behalf of the player, zero if the player timed out
+	// while betting.
+	Action Action
+}
+
+// PlayerSatOut is emitted when a player has timed out too many times in a row.
+type PlayerSatOut struct {
+	// Player is the name of the player.
+	Player string
+}
+
+func (PlayerSeated) Name() string { return "PlayerSeated" }
+func (PlayerLeft) Name() string   { return "PlayerLeft" }
+func (TurnStarted) Name() string  { return "TurnStarted" }
+func (TurnTimedOut) Name() string { return "TurnTimedOut" }
+func (PlayerSatOut) Name() string { return "PlayerSatOut" }
+
+// NewTable returns an empty table. A table takes a single wager on every hand, so
+// Multi-Action is played as a single bet on each hand.
+func NewTable(c TableConfig) *Table {
+	t := &Table{
+		game:        New(),
+		seed:        c.Seed,
+		bets:        make(map[spot]int),
+		subscribers: make(map[int]func(TableEvent)),
+		clock:       c.Clock,
+		timeouts:    c.Timeouts,
+		strikes:     make(map[*Player]int),
+		sittingOut:  make(map[*Player]bool),
+	}
+	if t.seed == nil {
+		t.seed = func() int64 { return time.Now().UnixNano() }
+	}
+	if t.clock == nil {
+		t.clock = SystemClock
+	}
+	t.game.Rules = c.Rules
+	t.game.Listen(func(e Event) {
+		if settled, ok := e.(HandSettled); ok {
+			t.results = append(t.results, Settlement{
+				Hand:   settled.Hand.ID,
+				Seat:   settled.Hand.Seat,
+				Player: settled.Hand.Player.Name,
+				State:  settled.State,
+				Payout: settled.Payout,
+				Bet:    settled.Bet,
+			})
+		}
+		t.publish(e)
+	})
+	return t
+}
+
+// Subscribe registers the function to be called with every event of the table, and
+// returns the state of the table as seen by the named player at the time of the
+// subscription. The function is called while the table is locked, so it must not call
+// the methods of the table. Calling the returned function cancels the subscription.
+func (t *Table) Subscribe(name string, f func(TableEvent)) (TableState, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.nextSub
+	t.nextSub++
+	t.subscribers[id] = f
+	cancel := func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.subscribers, id)
+	}
+	return t.stateFor(name), cancel
+}
+
+// State returns the state of the table as seen by the named player.
+func (t *Table) State(name string) TableState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stateFor(name)
+}
+
+// Snapshot returns the complete state of the table's game.
+func (t *Table) Snapshot() Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.game.Snapshot()
+}
+
+// Rules returns the rules the table is played with.
+func (t *Table) Rules() Rules {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.game.Rules
+}
+
+// Players returns the names of the players seated at the table.
+func (t *Table) Players() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make([]string, 0, len(t.players))
+	for _, p := range t.players {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// Join seats a new player with the specified name.
+func (t *Table) Join(name string) error {
+	return t.Seat(NewPlayer(name))
+}
+
+// Seat seats the specified player at the lowest numbered empty seat, keeping their
+// winnings.
+func (t *Table) Seat(p *Player) error {
+	return t.Sit(p, 0)
+}
+
+// Sit seats the specified player at the numbered seat, keeping their winnings. Seat zero
+// is the lowest numbered empty seat, and the only seat of a table without seats. A player
+// who is already seated takes another spot, as long as the rules of the table allow it.
+func (t *Table) Sit(p *Player, seat int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p == nil || p.Name == "" {
+		return fmt.Errorf("%w: a player must have a name", ErrInvalidPlayer)
+	}
+	if t.closed {
+		return fmt.Errorf("%w: players may not join a closed table", ErrTableClosed)
+	}
+	if t.playing {
+		return fmt.Errorf("%w: players may only join between rounds", ErrRoundInProgress)
+	}
+	seats := t.game.Rules.Seats
+	if seated := t.player(p.Name); seated != nil {
+		if seated != p || seats == 0 {
+			return fmt.Errorf("%w: %s is already seated", ErrAlreadySeated, p.Name)
+		}
+		if limit := t.game.Rules.SpotLimit(); len(t.game.SeatsOf(p)) >= limit {
+			return fmt.Errorf("%w: a player may play at most %d seats", ErrSpotLimit, limit)
+		}
+	}
+
+	switch {
+	case seats == 0 && seat != 0:
+		return fmt.Errorf("%w: the table has no seats", ErrInvalidSeat)
+	case seats == 0:
+		t.players = append(t.players, p)
+		t.game.AddPlayer(p)
+		t.publish(PlayerSeated{Player: p.Name})
+		return nil
+	case seat == 0:
+		if seat = t.game.EmptySeat(); seat == 0 {
+			return fmt.Errorf("%w: all %d seats are taken", ErrTableFull, seats)
+		}
+	case seat < 0 || seat > seats:
+		return fmt.Errorf("%w: seats are numbered from 1 to %d", ErrInvalidSeat, seats)
+	case t.game.SeatedAt(seat) != nil:
+		return fmt.Errorf("%w: seat %d is taken by %s", ErrSeatTaken, seat, t.game.SeatedAt(seat).Name)
+	}
+	t.game.Sit(p, seat)
+	t.players = t.game.Seated()
+	t.publish(PlayerSeated{Player: p.Name, Seat: seat})
+	return nil
+}
+
+// Leave removes the named player from the table, returning the player so that their
+// winnings can be kept.
+func (t *Table) Leave(name string) (*Player, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.player(name)
+	if p == nil {
+		return nil, fmt.Errorf("%w: %s is not seated", ErrPlayerNotFound, name)
+	}
+	if t.playing {
+		return nil, fmt.Errorf("%w: players may only leave between rounds", ErrRoundInProgress)
+	}
+	for i := range t.players {
+		if t.players[i] == p {
+			t.players = append(t.players[:i], t.players[i+1:]...)
+			break
+		}
+	}
+	for s := range t.bets {
+		if s.player == p {
+			delete(t.bets, s)
+		}
+	}
+	delete(t.strikes, p)
+	delete(t.sittingOut, p)
+	if !t.game.Stand(p) {
+		t.game.RemovePlayer(p)
+	}
+	t.publish(PlayerLeft{Player: name})
+	if t.ready() && !t.closed {
+		t.deal()
+	}
+	return p, nil
+}
+
+// Bet places the wager of the named player on each of their seats for the next round.
+// The first bet of a round starts the betting deadline.
+func (t *Table) Bet(name string, wager int) error {
+	return t.BetAt(name, 0, wager)
+}
+
+// BetAt places the wager of the named player on one of their seats for the next round,
+// or on each of their seats for seat zero. Seats the player has not bet on are not dealt.
+func (t *Table) BetAt(name string, seat int, wager int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.player(name)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated", ErrPlayerNotFound, name)
+	}
+	if seat != 0 && (t.game.Rules.Seats == 0 || t.game.SeatedAt(seat) != p) {
+		return fmt.Errorf("%w: %s is not seated at seat %d", ErrInvalidSeat, name, seat)
+	}
+	if t.playing {
+		return fmt.Errorf("%w: bets may only be placed between rounds", ErrRoundInProgress)
+	}
+	if t.closed {
+		return fmt.Errorf("%w: bets may not be placed at a closed table", ErrTableClosed)
+	}
+	if wager <= 0 || !t.game.Rules.AllowsBet(wager) {
+		return fmt.Errorf("%w: a wager of %d is not allowed at this table", ErrInvalidBet, wager)
+	}
+	// the first bet of the round starts the betting timer, however many spots it covers
+	first := len(t.bets) == 0
+	for _, s := range t.spots() {
+		if s.player == p && (seat == 0 || s.seat == seat) {
+			t.bets[s] = wager
+		}
+	}
+	if t.sittingOut[p] {
+		t.sittingOut[p] = false
+		t.strikes[p] = 0
+	}
+	if t.ready() {
+		t.deal()
+	} else if first {
+		t.startBetting()
+	}
+	return nil
+}
+
+// Act takes the action for the named player, who must be the player whose turn it is.
+func (t *Table) Act(name string, a Action) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.player(name)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated", ErrPlayerNotFound, name)
+	}
+	if !t.playing {
+		return fmt.Errorf("%w: the table is waiting on bets", ErrRoundNotStarted)
+	}
+	if current := t.game.current().Player; current != p {
+		return fmt.Errorf("%w: it is %s's turn", ErrNotYourTurn, current.Name)
+	}
+	if !t.game.Dealer.Act(a) {
+		return fmt.Errorf("%w: %s may not %s", ErrIllegalAction, name, t.game.Rules.Variant.ActionName(a))
+	}
+	t.strikes[p] = 0
+	t.advance()
+	return nil
+}
+
+// Close stops the timeouts of the table and refuses any further players and bets, so
+// that its players can leave without another round being dealt. A table may only be
+// closed between rounds.
+func (t *Table) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.playing {
+		return fmt.Errorf("%w: a table may only be closed between rounds", ErrRoundInProgress)
+	}
+	t.closed = true
+	t.stopBetting()
+	t.stopTurn()
+	return nil
+}
+
+func (t *Table) player(name string) *Player {
+	for _, p := range t.players {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// publish sends the event to every subscriber. The lock of the table must be held.
+func (t *Table) publish(e Event) {
+	t.seq++
+	te := TableEvent{Seq: t.seq, Hand: -1, Event: e}
+	var val *ListVal
+	switch e := e.(type) {
+	case CardDealt:
+		val = e.Hand
+	case BetPlaced:
+		val = e.Hand
+	case ActionTaken:
+		val = e.Hand
+	case HandBusted:
+		val = e.Hand
+	case HandSettled:
+		val = e.Hand
+	case BackBetPlaced:
+		val = e.Hand
+	case BackBetSettled:
+		val = e.Hand
+	case SideBetPlaced:
+		val = e.Hand
+	case SideBetSettled:
+		val = e.Hand
+	}
+	if val != nil {
+		te.Hand = t.game.position(val)
+		te.HandID = val.ID
+	}
+	for _, f := range t.subscribers {
+		f(te)
+	}
+}
+
+func (t *Table) stateFor(name string) TableState {
+	s := TableState{
+		Seq:     t.seq,
+		Rules:   t.game.Rules,
+		Playing: t.playing,
+		View:    t.game.ViewFor(t.player(name)),
+		Results: append([]Settlement{}, t.results...),
+	}
+	if current := t.game.current(); current != nil {
+		s.Turn = current.Player.Name
+	}
+	for _, sp := range t.spots() {
+		s.Seats = append(s.Seats, Seat{
+			Number:     sp.seat,
+			Player:     sp.player.Name,
+			Winnings:   sp.player.Winnings,
+			Bet:        t.bets[sp],
+			SittingOut: t.sittingOut[sp.player],
+		})
+	}
+	return s
+}
+
+// spots returns the spots played at the table in the order they are dealt.
+func (t *Table) spots() []spot {
+	var spots []spot
+	if t.game.Rules.Seats == 0 {
+		for _, p := range t.players {
+			spots = append(spots, spot{player: p})
+		}
+		return spots
+	}
+	for seat := 1; seat <= t.game.Rules.Seats; seat++ {
+		if p := t.game.SeatedAt(seat); p != nil {
+			spots = append(spots, spot{player: p, seat: seat})
+		}
+	}
+	return spots
+}
+
+// ready returns true if a bet has been placed and every spot of the players who are not
+// sitting out has a bet.
+func (t *Table) ready() bool {
+	if len(t.bets) == 0 {
+		return false
+	}
+	for _, s := range t.spots() {
+		if _, ok := t.bets[s]; !ok && !t.sittingOut[s.player] {
+			return false
+		}
+	}
+	return true
+}
+
+// deal starts a new round for the players who have bet.
+func (t *Table) deal() {
+	t.stopBetting()
+
+	dealer := t.game.Dealer
+	dealer.Clear()
+	t.game.Hands().Clear()
+	spots := t.spots()
+	for _, s := range spots {
+		if _, ok := t.bets[s]; ok {
+			t.game.add(&ListVal{Player: s.player, Seat: s.seat})
+		}
+	}
+
+	v := t.game.Rules.variant()
+	shoe := len(v.deck()) * t.game.Rules.ShoeSize()
+	if dealer.Remaining() < shoe/4 || dealer.Remaining() < 10*(len(spots)*v.hands()+1) {
+		dealer.UseRules()
+		dealer.Shuffle(t.seed())
+	}
+
+	for _, val := range t.game.Hands().All() {
+		dealer.Bet(val, t.bets[spot{player: val.Player, seat: val.Seat}])
+	}
+	t.bets = make(map[spot]int)
+	t.results = nil
+	t.playing = true
+
+	dealer.Deal(2, t.game.Players)
+	t.game.Start()
+	t.turn = nil
+	t.startTurn()
+}
+
+// advance moves past a busted hand and settles the round once every hand has been
+// played, or restarts the turn deadline.
+func (t *Table) advance() {
+	t.game.Dealer.Evaluate()
+	if t.game.PlayersPlayed() {
+		t.game.Dealer.Play()
+		t.game.Dealer.Collect()
+		t.playing = false
+	}
+	t.startTurn()
+}
+
+// startTurn restarts the deadline of the current hand and announces a change of turn.
+func (t *Table) startTurn() {
+	t.stopTurn()
+
+	var deadline time.Time
+	current := t.game.current()
+	if t.timeouts.Turn > 0 && t.playing && current != nil && !t.closed {
+		t.turnGen++
+		gen := t.turnGen
+		deadline = t.clock.Now().Add(t.timeouts.Turn)
+		t.turnTimer = t.clock.AfterFunc(t.timeouts.Turn, func() {
+			t.turnTimeout(gen)
+		})
+	}
+
+	if current != t.turn {
+		t.turn = current
+		e := TurnStarted{Deadline: deadline}
+		if current != nil {
+			e.Player = current.Player.Name
+		}
+		t.publish(e)
+	}
+}
+
+func (t *Table) stopTurn() {
+	if t.turnTimer != nil {
+		t.turnTimer.Stop()
+		t.turnTimer = nil
+	}
+}
+
+// turnTimeout acts on behalf of the player whose turn has timed out.
+func (t *Table) turnTimeout(gen int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if gen != t.turnGen || !t.playing || t.game.current() == nil || t.closed {
+		return
+	}
+	p := t.game.current().Player
+	a := Stay
+	if t.timeouts.Auto == AutoBasicStrategy {
+		a = t.game.Dealer.Suggest()
+	}
+	t.publish(TurnTimedOut{Player: p.Name, Action: a})
+	val := t.game.current()
+	if !t.game.Dealer.Act(a) && !t.game.Dealer.Stay() {
+		// a hand that may not stay, such as a Pontoon hand below 15, hits until it may
+		for t.game.current() == val && !t.game.Dealer.Stay() && t.game.Dealer.Hit() {
+			t.game.Dealer.Evaluate()
+		}
+		// the shoe may have run out of cards for the hand to hit
+		if t.game.current() == val {
+			t.game.EndPlayerTurn()
+		}
+	}
+	t.strike(p)
+	t.advance()
+}
+
+// startBetting starts the betting deadline.
+func (t *Table) startBetting() {
+	if t.timeouts.Betting == 0 || t.closed {
+		return
+	}
+	t.betGen++
+	gen := t.betGen
+	t.betTimer = t.clock.AfterFunc(t.timeouts.Betting, func() {
+		t.bettingTimeout(gen)
+	})
+}
+
+func (t *Table) stopBetting() {
+	if t.betTimer != nil {
+		t.betTimer.Stop()
+		t.betTimer = nil
+	}
+}
+
+// bettingTimeout deals the round to the players who have bet.
+func (t *Table) bettingTimeout(gen int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if gen != t.betGen || t.playing || len(t.bets) == 0 || t.closed {
+		return
+	}
+	bet := make(map[*Player]bool)
+	for s := range t.bets {
+		bet[s.player] = true
+	}
+	for _, p := range t.players {
+		if !bet[p] && !t.sittingOut[p] {
+			t.publish(TurnTimedOut{Player: p.Name})
+			t.strike(p)
+		}
+	}
+	t.deal()
+}
+
+// strike counts a timeout against the player, sitting them out once they have timed out
+// too many times in a row.
+func (t *Table) strike(p *Player) {
+	t.strikes[p]++
+	if t.timeouts.SitOut > 0 && t.strikes[p] >= t.timeouts.SitOut && !t.sittingOut[p] {
+		t.sittingOut[p] = true
+		t.publish(PlayerSatOut{Player: p.Name})
+	}
+}
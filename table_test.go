@@ -0,0 +1,324 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTableRound(t *testing.T) {
+	table := NewTable(TableConfig{Seed: func() int64 { return 1 }})
+	var events []TableEvent
+	table.Subscribe("", func(e TableEvent) {
+		events = append(events, e)
+	})
+
+	for _, name := range []string{"a", "b"} {
+		if err := table.Join(name); err != nil {
+			t.Fatalf("expected %s to join but got %v", name, err)
+		}
+	}
+	if err := table.Join("a"); !errors.Is(err, ErrAlreadySeated) {
+		t.Fatalf("expected a second seat for a to be refused but got %v", err)
+	}
+	if err := table.Act("a", Stay); !errors.Is(err, ErrRoundNotStarted) {
+		t.Fatalf("expected an action before the round to be refused but got %v", err)
+	}
+	if err := table.Bet("a", 5); err != nil {
+		t.Fatalf("expected a to bet but got %v", err)
+	}
+	if table.State("").Playing {
+		t.Fatalf("expected the round to wait on b's bet")
+	}
+	if err := table.Bet("b", 5); err != nil {
+		t.Fatalf("expected b to bet but got %v", err)
+	}
+
+	state := table.State("a")
+	if !state.Playing || state.Turn != "a" || len(state.View.Hands) != 2 {
+		t.Fatalf("expected the round to be dealt to a and b with a to act")
+	}
+	if err := table.Join("c"); !errors.Is(err, ErrRoundInProgress) {
+		t.Fatalf("expected joining during a round to be refused but got %v", err)
+	}
+	if err := table.Act("b", Stay); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("expected b acting out of turn to be refused but got %v", err)
+	}
+	if err := table.Act("a", Stay); err != nil {
+		t.Fatalf("expected a to stay but got %v", err)
+	}
+	if err := table.Act("b", Stay); err != nil {
+		t.Fatalf("expected b to stay but got %v", err)
+	}
+
+	state = table.State("")
+	if state.Playing || len(state.Results) != 2 {
+		t.Fatalf("expected the round to be settled with two results")
+	}
+	if state.Seq != len(events) {
+		t.Fatalf("expected the table to be at the sequence number of its last event")
+	}
+	for i, e := range events {
+		if e.Seq != i+1 {
+			t.Fatalf("expected event %d to have sequence number %d but was %d", i, i+1, e.Seq)
+		}
+		if _, ok := e.Event.(CardDealt); ok && e.Event.(CardDealt).Hand != nil && e.Hand < 0 {
+			t.Fatalf("expected a card dealt to a player to have the position of the hand")
+		}
+	}
+
+	p, err := table.Leave("a")
+	if err != nil || p.Name != "a" {
+		t.Fatalf("expected a to leave but got %v", err)
+	}
+	if names := table.Players(); len(names) != 1 || names[0] != "b" {
+		t.Fatalf("expected only b to be seated but was %v", names)
+	}
+}
+
+func TestTableTimeouts(t *testing.T) {
+	clock := NewFakeClock(time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC))
+	table := NewTable(TableConfig{
+		Timeouts: Timeouts{Turn: 30 * time.Second, Betting: 10 * time.Second, SitOut: 2},
+		Clock:    clock,
+		Seed:     func() int64 { return 1 },
+	})
+	table.Join("a")
+	table.Join("b")
+
+	table.Bet("a", 5)
+	clock.Advance(10 * time.Second)
+	if state := table.State("a"); !state.Playing || len(state.View.Hands) != 1 {
+		t.Fatalf("expected the round to be dealt to a alone once betting timed out")
+	}
+	clock.Advance(30 * time.Second)
+	if table.State("").Playing {
+		t.Fatalf("expected the round to be settled once a's turn timed out")
+	}
+
+	table.Bet("a", 5)
+	clock.Advance(10 * time.Second)
+	state := table.State("")
+	if state.Seats[0].SittingOut || !state.Seats[1].SittingOut {
+		t.Fatalf("expected only b to sit out after timing out twice in a row")
+	}
+
+	if err := table.Close(); !errors.Is(err, ErrRoundInProgress) {
+		t.Fatalf("expected closing the table during a round to be refused but got %v", err)
+	}
+	clock.Advance(30 * time.Second)
+	if err := table.Close(); err != nil {
+		t.Fatalf("expected the table to close between rounds but got %v", err)
+	}
+	if err := table.Bet("a", 5); !errors.Is(err, ErrTableClosed) {
+		t.Fatalf("expected a bet at a closed table to be refused but got %v", err)
+	}
+}
+
+func TestTableSeats(t *testing.T) {
+	table := NewTable(TableConfig{Rules: Rules{Seats: 3}, Seed: func() int64 { return 1 }})
+
+	if err := table.Sit(NewPlayer("c"), 3); err != nil {
+		t.Fatalf("expected c to sit at third base but got %v", err)
+	}
+	if err := table.Sit(NewPlayer("d"), 3); !errors.Is(err, ErrSeatTaken) {
+		t.Fatalf("expected a taken seat to be refused but got %v", err)
+	}
+	if err := table.Sit(NewPlayer("d"), 4); !errors.Is(err, ErrInvalidSeat) {
+		t.Fatalf("expected a seat the table does not have to be refused but got %v", err)
+	}
+	table.Join("a")
+	table.Join("b")
+	if err := table.Join("d"); !errors.Is(err, ErrTableFull) {
+		t.Fatalf("expected a full table to be refused but got %v", err)
+	}
+
+	state := table.State("")
+	for i, name := range []string{"a", "b", "c"} {
+		if seat := state.Seats[i]; seat.Player != name || seat.Number != i+1 {
+			t.Fatalf("expected %s at seat %d but was %s at seat %d", name, i+1, seat.Player, seat.Number)
+		}
+	}
+
+	table.Leave("b")
+	table.Bet("c", 5)
+	table.Bet("a", 5)
+	state = table.State("")
+	if state.Turn != "a" || len(state.View.Hands) != 2 || state.View.Hands[1].Seat != 3 {
+		t.Fatalf("expected the empty seat to be skipped and a at first base to act first")
+	}
+}
+
+func TestTableSpots(t *testing.T) {
+	table := NewTable(TableConfig{Rules: Rules{Seats: 4, Spots: 2}, Seed: func() int64 { return 1 }})
+	a, b := NewPlayer("a"), NewPlayer("b")
+	table.Sit(a, 1)
+	table.Sit(b, 2)
+	if err := table.Sit(a, 4); err != nil {
+		t.Fatalf("expected a to take a second spot but got %v", err)
+	}
+	if err := table.Sit(a, 3); !errors.Is(err, ErrSpotLimit) {
+		t.Fatalf("expected a third spot for a to be refused but got %v", err)
+	}
+	if err := table.BetAt("a", 2, 5); !errors.Is(err, ErrInvalidSeat) {
+		t.Fatalf("expected a bet on b's seat to be refused but got %v", err)
+	}
+
+	table.BetAt("a", 1, 10)
+	table.Bet("b", 5)
+	if table.State("").Playing {
+		t.Fatalf("expected the round to wait on a's bet at seat 4")
+	}
+	table.BetAt("a", 4, 20)
+
+	state := table.State("a")
+	if !state.Playing || len(state.View.Hands) != 3 {
+		t.Fatalf("expected the round to be dealt to three spots")
+	}
+	wagers := []int{10, 5, 20}
+	for i, hand := range state.View.Hands {
+		if hand.Wager != wagers[i] {
+			t.Fatalf("expected hand %d to have a wager of %d but was %d", i, wagers[i], hand.Wager)
+		}
+	}
+
+	for table.State("").Playing {
+		table.Act(table.State("").Turn, Stay)
+	}
+	results := table.State("").Results
+	if len(results) != 3 || results[0].Seat != 1 || results[2].Seat != 4 || results[2].Player != "a" {
+		t.Fatalf("expected each spot to be settled separately")
+	}
+	if _, err := table.Leave("a"); err != nil || len(table.State("").Seats) != 1 {
+		t.Fatalf("expected a to leave both of their spots")
+	}
+}
+
+func TestTableSpotsBettingTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC))
+	table := NewTable(TableConfig{
+		Rules:    Rules{Seats: 4, Spots: 2},
+		Timeouts: Timeouts{Betting: 10 * time.Second},
+		Clock:    clock,
+		Seed:     func() int64 { return 1 },
+	})
+	a, b := NewPlayer("a"), NewPlayer("b")
+	table.Sit(a, 1)
+	table.Sit(a, 2)
+	table.Sit(b, 3)
+
+	table.Bet("a", 5)
+	clock.Advance(10 * time.Second)
+	if state := table.State("a"); !state.Playing || len(state.View.Hands) != 2 {
+		t.Fatalf("expected the round to be dealt to both of a's spots once betting timed out")
+	}
+}
+
+func TestTableSwitchShoe(t *testing.T) {
+	// a single deck is refused by Rules.Validate, but the round must not run past the
+	// end of the shoe at a table created without it
+	table := NewTable(TableConfig{Rules: Rules{Seats: 7, Variant: BlackjackSwitch}, Seed: func() int64 { return 1 }})
+	for i := 1; i <= 7; i++ {
+		table.Sit(NewPlayer(fmt.Sprint(i)), i)
+	}
+	for i := 1; i <= 7; i++ {
+		table.Bet(fmt.Sprint(i), 5)
+	}
+	// every hand hits for as long as it may, which runs the shoe out of cards
+	for turns := 0; table.State("").Playing; turns++ {
+		if turns > 200 {
+			t.Fatalf("expected the round to be settled")
+		}
+		turn := table.State("").Turn
+		if table.Act(turn, Hit) != nil {
+			table.Act(turn, Stay)
+		}
+	}
+	if results := table.State("").Results; len(results) != 14 {
+		t.Fatalf("expected all fourteen hands to be settled but got %d", len(results))
+	}
+}
+
+func TestTableConcurrent(t *testing.T) {
+	table := NewTable(TableConfig{Rules: Rules{Decks: 6}})
+	players := 5
+	rounds := 20
+	dealt := 0
+	table.Subscribe("", func(e TableEvent) {
+		if _, ok := e.Event.(RoundStarted); ok {
+			dealt++
+		}
+	})
+
+	for i := 0; i < players; i++ {
+		if err := table.Join(fmt.Sprint(i)); err != nil {
+			t.Fatalf("expected %d to join but got %v", i, err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < players; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for bets := 0; ; runtime.Gosched() {
+				state := table.State(name)
+				if state.Turn == name {
+					if table.Act(name, Hit) != nil {
+						table.Act(name, Stay)
+					}
+					continue
+				}
+				if state.Playing {
+					continue
+				}
+				if bets == rounds {
+					if _, err := table.Leave(name); err == nil {
+						return
+					}
+					continue
+				}
+				if mine(state, name).Bet > 0 {
+					continue
+				}
+				if table.Bet(name, 5) == nil {
+					bets++
+				}
+			}
+		}(fmt.Sprint(i))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			if names := table.Players(); len(names) != 0 {
+				t.Fatalf("expected every player to have left but %v are seated", names)
+			}
+			if dealt < rounds {
+				t.Fatalf("expected at least %d rounds to be dealt but was %d", rounds, dealt)
+			}
+			return
+		default:
+			table.Snapshot()
+			table.State("")
+			runtime.Gosched()
+		}
+	}
+}
+
+func mine(state TableState, name string) Seat {
+	for _, seat := range state.Seats {
+		if seat.Player == name {
+			return seat
+		}
+	}
+	return Seat{}
+}
@@ -0,0 +1,224 @@
+package blackjack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethanefung/cards"
+)
+
+// Variant is a game played on the blackjack engine with its own deck, outcome, payout
+// and action rules.
+type Variant int
+
+const (
+	// Standard is blackjack as dealt by the engine, where every winning hand, naturals
+	// included, pays even money.
+	Standard Variant = iota
+	// Spanish21 is played without tens. A player's 21 always wins, naturals pay 3 to 2
+	// and 21s of five or more cards, 6-7-8 and 7-7-7 pay bonuses. Players may double on
+	// any number of cards, rescue a doubled hand by surrendering it, and surrender late.
+	Spanish21
+	// BlackjackSwitch deals two hands to every seat, and a player may switch the second
+	// cards of their hands before playing them. Naturals pay even money and beat any
+	// other 21, while a dealer's 22 pushes every hand that has not bust.
+	BlackjackSwitch
+	// DoubleExposure deals both of the dealer's cards face up. The dealer wins every tie
+	// but a natural against a natural, and naturals pay even money.
+	DoubleExposure
+	// FreeBet doubles hard 9, 10 and 11 and splits every pair but tens for free, with the
+	// house putting up the extra wager, which is only paid when the hand wins. A dealer's
+	// 22 pushes every hand that has not bust.
+	FreeBet
+	// Pontoon is the British game, where both of the dealer's cards are dealt face down
+	// and actions are named twist, stick and buy. A pontoon beats a five card trick,
+	// which beats every other hand, and the dealer wins every tie. Players must twist
+	// below 15 and may buy one card, doubling the wager, on fewer than five cards.
+	Pontoon
+	// MultiAction plays two or three bets on a single hand. The dealer keeps the same
+	// upcard for every bet, drawing a fresh hand to it to settle each bet after the
+	// first.
+	MultiAction
+)
+
+//go:generate stringer -type=Variant
+
+// ParseVariant returns the Variant with the specified name, ignoring case.
+func ParseVariant(name string) (Variant, bool) {
+	for v := Variant(0); int(v) < len(_Variant_index)-1; v++ {
+		if strings.EqualFold(v.String(), name) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// MarshalText encodes the variant as its name.
+func (v Variant) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a variant from its name.
+func (v *Variant) UnmarshalText(text []byte) error {
+	parsed, ok := ParseVariant(string(text))
+	if !ok {
+		return fmt.Errorf("blackjack: unknown variant %q", text)
+	}
+	*v = parsed
+	return nil
+}
+
+// ActionName returns the name of the action in the variant's vocabulary, such as "Twist"
+// for Hit in Pontoon.
+func (v Variant) ActionName(a Action) string {
+	if name, ok := v.behaviour().names()[a]; ok {
+		return name
+	}
+	return a.String()
+}
+
+// ParseAction returns the Action with the specified name in the variant's vocabulary,
+// ignoring case.
+func (v Variant) ParseAction(name string) (Action, bool) {
+	names := v.behaviour().names()
+	if names == nil {
+		return ParseAction(name)
+	}
+	for a, n := range names {
+		if strings.EqualFold(n, name) {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// variant is the behaviour of a Variant. Variants embed standard and override the rules
+// they change.
+type variant interface {
+	// deck returns a single deck of the variant.
+	deck() cards.Deck
+	// prepare readies the hands of the game for a round before the first card is dealt.
+	prepare(g *Game)
+	// hands returns the number of hands dealt to every seat.
+	hands() int
+	// hidden returns true if the dealer's card at the specified position is dealt face
+	// down.
+	hidden(i int) bool
+	// outcome returns the WinType of a hand that has not bust against the dealer's
+	// final hand.
+	outcome(val *ListVal, dealer Hand) WinType
+	// payout returns the change to a player's winnings for a wager on a hand settled
+	// with the specified WinType.
+	payout(val *ListVal, t WinType, wager int) int
+	// allows returns true if the action may be taken on the current hand.
+	allows(g *Game, val *ListVal, a Action) bool
+	// rescues returns true if a doubled hand stays in play, such as to be surrendered,
+	// rather than ending the turn.
+	rescues() bool
+	// done returns true if the hand may take no more cards, ending the turn.
+	done(val *ListVal) bool
+	// charlies returns true if Rules.Charlie may apply to the variant.
+	charlies() bool
+	// free returns true if the house puts up the extra wager of a double or split of the
+	// hand.
+	free(val *ListVal, a Action) bool
+	// names returns the name of every action of the variant, or nil if the actions go
+	// by their own names.
+	names() map[Action]string
+}
+
+// variant returns the behaviour of the rules' variant.
+func (r Rules) variant() variant {
+	return r.Variant.behaviour()
+}
+
+// behaviour returns the behaviour of the variant.
+func (v Variant) behaviour() variant {
+	switch v {
+	case Spanish21:
+		return spanish21{}
+	case BlackjackSwitch:
+		return blackjackSwitch{}
+	case DoubleExposure:
+		return doubleExposure{}
+	case FreeBet:
+		return freeBet{}
+	case Pontoon:
+		return pontoon{}
+	case MultiAction:
+		return multiAction{}
+	}
+	return standard{}
+}
+
+// standard is the behaviour of Standard blackjack.
+type standard struct{}
+
+func (standard) deck() cards.Deck {
+	return cards.New()
+}
+
+func (standard) prepare(g *Game) {}
+
+func (standard) hands() int {
+	return 1
+}
+
+func (standard) hidden(i int) bool {
+	return i == 0
+}
+
+func (standard) outcome(val *ListVal, dealer Hand) WinType {
+	playerHand, dealerHand := val.Hand.Value(), dealer.Value()
+	if playerHand == dealerHand {
+		return Push
+	} else if playerHand > dealerHand || dealerHand > 21 {
+		return Win
+	}
+	return Lose
+}
+
+func (standard) payout(val *ListVal, t WinType, wager int) int {
+	switch t {
+	case Win:
+		return wager
+	case Lose, Bust:
+		return -wager
+	}
+	return 0
+}
+
+func (standard) allows(g *Game, val *ListVal, a Action) bool {
+	switch a {
+	case Double:
+		return len(val.Hand) <= 2
+	case Switch:
+		return false
+	}
+	return true
+}
+
+func (standard) rescues() bool {
+	return false
+}
+
+func (standard) done(val *ListVal) bool {
+	return val.Hand.Value() > 21
+}
+
+func (standard) charlies() bool {
+	return true
+}
+
+func (standard) free(val *ListVal, a Action) bool {
+	return false
+}
+
+func (standard) names() map[Action]string {
+	return nil
+}
+
+// natural returns true if the hand is a two card 21 that was neither split nor switched.
+func (val *ListVal) natural() bool {
+	return len(val.Hand) == 2 && val.Hand.Value() == 21 && !val.Split && !val.Switched
+}
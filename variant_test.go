@@ -0,0 +1,35 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseVariant(t *testing.T) {
+	for _, v := range []Variant{Standard, Spanish21} {
+		parsed, ok := ParseVariant(v.String())
+		if !ok || parsed != v {
+			t.Fatalf("expected %s to be parsed but got %v", v, parsed)
+		}
+	}
+	if v, ok := ParseVariant("spanish21"); !ok || v != Spanish21 {
+		t.Fatalf("expected variant names to be parsed ignoring case")
+	}
+	if _, ok := ParseVariant("pinochle"); ok {
+		t.Fatalf("expected pinochle to not be a variant")
+	}
+}
+
+func TestRulesVariant(t *testing.T) {
+	b, err := json.Marshal(Rules{Variant: Spanish21})
+	if err != nil {
+		t.Fatalf("expected the rules to be encoded but got %v", err)
+	}
+	var rules Rules
+	if err := json.Unmarshal(b, &rules); err != nil || rules.Variant != Spanish21 {
+		t.Fatalf("expected the variant to be decoded by name from %s", b)
+	}
+	if err := (Rules{Variant: Variant(-1)}).Validate(); err == nil {
+		t.Fatalf("expected an unknown variant to be refused")
+	}
+}
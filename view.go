@@ -0,0 +1,116 @@
+package blackjack
+
+// View is the part of a game that a single player is allowed to see. Unlike a Snapshot
+// a View never contains the dealer's hole card, unless the game's variant deals it face
+// up, or the order of the shoe, so it is safe to send to untrusted clients.
+type View struct {
+	// Dealer is the dealer's hand as seen from the table.
+	Dealer DealerView `json:"dealer"`
+	// Hands are the hands at the table in the order they are played.
+	Hands []HandView `json:"hands"`
+	// Current is the index of the hand whose turn it is within Hands, or -1 if no hand
+	// is being played.
+	Current int `json:"current"`
+	// Remaining is the number of cards left in the shoe.
+	Remaining int `json:"remaining"`
+	// Winnings are the winnings of the player the view was made for.
+	Winnings int `json:"winnings"`
+}
+
+// DealerView is the dealer's hand as seen from the table.
+type DealerView struct {
+	// Cards are the dealer's face up cards.
+	Cards Hand `json:"cards"`
+	// Hidden is the number of the dealer's cards that are face down.
+	Hidden int `json:"hidden"`
+	// Value is the value of the dealer's face up cards.
+	Value int `json:"value"`
+	// Hands are the dealer's hands drawn for the bets after the first of Multi-Action
+	// hands, once they have been played.
+	Hands []Hand `json:"hands,omitempty"`
+}
+
+// HandView is a hand at the table as seen by a player.
+type HandView struct {
+	// ID is the ID of the hand.
+	ID int `json:"id"`
+	// Player is the name of the hand's owner.
+	Player string `json:"player"`
+	// Seat is the seat the hand is played from, zero at a table without seats.
+	Seat int `json:"seat,omitempty"`
+	// Mine is whether the hand belongs to the player the view was made for.
+	Mine bool `json:"mine"`
+	// Cards are the cards of the hand.
+	Cards Hand `json:"cards"`
+	// Value is the value of the hand.
+	Value int `json:"value"`
+	// Wager is the wager placed on the hand.
+	Wager int `json:"wager"`
+	// Extra are the wagers after Wager of a Multi-Action hand.
+	Extra []int `json:"extra,omitempty"`
+	// Free is the wager put up by the house for a free double or split of the hand.
+	Free int `json:"free,omitempty"`
+	// Backed is the total wagered on the hand by other players.
+	Backed int `json:"backed,omitempty"`
+	// MyBackBet is the wager the player the view was made for has placed on the hand.
+	MyBackBet int `json:"myBackBet,omitempty"`
+	// SideBets are the wagers on side bets placed on the hand by its owner.
+	SideBets []SideBetResult `json:"sideBets,omitempty"`
+}
+
+// PublicView returns the game as seen by a spectator who has no hands at the table.
+func (g *Game) PublicView() View {
+	return g.ViewFor(nil)
+}
+
+// ViewFor returns the game as seen by the specified player.
+func (g *Game) ViewFor(p *Player) View {
+	hands := g.Dealer.Hands()
+	shown := hands[0]
+	v := View{
+		Dealer: DealerView{
+			Cards:  shown,
+			Hidden: len(g.Dealer.hand) - len(shown),
+			Value:  shown.Value(),
+			Hands:  hands[1:],
+		},
+		Current:   -1,
+		Remaining: g.Dealer.Remaining(),
+	}
+	if p != nil {
+		v.Winnings = p.Winnings
+	}
+
+	current := g.current()
+	for _, val := range g.Hands().All() {
+		if val == current {
+			v.Current = len(v.Hands)
+		}
+		var backed, mine int
+		for _, bet := range val.BackBets {
+			backed += bet.Wager
+			if p != nil && bet.Player == p {
+				mine += bet.Wager
+			}
+		}
+		var sideBets []SideBetResult
+		for _, w := range val.SideBets {
+			sideBets = append(sideBets, w.Result())
+		}
+		v.Hands = append(v.Hands, HandView{
+			ID:        val.ID,
+			Player:    val.Player.Name,
+			Seat:      val.Seat,
+			Mine:      p != nil && val.Player == p,
+			Cards:     append(Hand{}, val.Hand...),
+			Value:     val.Hand.Value(),
+			Wager:     val.Wager,
+			Extra:     append([]int(nil), val.Extra...),
+			Free:      val.Free,
+			Backed:    backed,
+			MyBackBet: mine,
+			SideBets:  sideBets,
+		})
+	}
+	return v
+}
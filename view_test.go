@@ -0,0 +1,80 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethanefung/cards"
+)
+
+func TestGameViewFor(t *testing.T) {
+	game := New()
+	a, b := NewPlayer("a"), NewPlayer("b")
+	game.AddPlayer(a)
+	game.AddPlayer(b)
+
+	hole := cards.Card{Rank: cards.Queen, Suit: cards.Diamonds}
+	game.Dealer.Stack(cards.Deck{
+		{Rank: cards.Two, Suit: cards.Spades},
+		{Rank: cards.Three, Suit: cards.Spades},
+		hole,
+		{Rank: cards.Four, Suit: cards.Spades},
+		{Rank: cards.Five, Suit: cards.Spades},
+		{Rank: cards.Six, Suit: cards.Spades},
+		{Rank: cards.Seven, Suit: cards.Spades},
+	})
+	game.Dealer.Deal(2, game.Players)
+	game.Start()
+
+	view := game.ViewFor(a)
+
+	if len(view.Dealer.Cards) != 1 || view.Dealer.Hidden != 1 {
+		t.Fatalf("expected the view to show one dealer card and hide one but shows %d and hides %d", len(view.Dealer.Cards), view.Dealer.Hidden)
+	}
+	if view.Dealer.Value != 6 {
+		t.Fatalf("expected the value of the dealer's upcard to be 6 but was %d", view.Dealer.Value)
+	}
+	if view.Current != 0 || !view.Hands[0].Mine || view.Hands[1].Mine {
+		t.Fatalf("expected the view to mark player a's hand as theirs and current")
+	}
+	if view.Remaining != 1 {
+		t.Fatalf("expected one card to remain in the shoe but view has %d", view.Remaining)
+	}
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		t.Fatalf("could not marshal view: %v", err)
+	}
+	var decoded View
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("could not unmarshal view: %v", err)
+	}
+	for _, card := range decoded.Dealer.Cards {
+		if card == hole {
+			t.Fatalf("expected the serialized view to not contain the dealer's hole card")
+		}
+	}
+	if strings.Contains(string(data), `"Rank":12`) {
+		t.Fatalf("expected the serialized view to not contain the dealer's hole card: %s", data)
+	}
+
+	game.Dealer.Stay()
+	game.Dealer.Stay()
+
+	view = game.PublicView()
+	if len(view.Dealer.Cards) != 2 || view.Dealer.Hidden != 0 {
+		t.Fatalf("expected the dealer's hand to be revealed after all players have played")
+	}
+	if view.Current != -1 || view.Hands[0].Mine {
+		t.Fatalf("expected a public view to have no current hand and no hands of its own")
+	}
+}
+
+func TestGameViewEmpty(t *testing.T) {
+	game := New()
+	view := game.PublicView()
+	if len(view.Hands) != 0 || len(view.Dealer.Cards) != 0 {
+		t.Fatalf("expected a view of an empty game to have no cards")
+	}
+}
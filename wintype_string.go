@@ -13,11 +13,12 @@ func _() {
 	_ = x[Win-2]
 	_ = x[Bust-3]
 	_ = x[Push-4]
+	_ = x[Charlie-5]
 }
 
-const _WinType_name = "UndeterminedLoseWinBustPush"
+const _WinType_name = "UndeterminedLoseWinBustPushCharlie"
 
-var _WinType_index = [...]uint8{0, 12, 16, 19, 23, 27}
+var _WinType_index = [...]uint8{0, 12, 16, 19, 23, 27, 34}
 
 func (i WinType) String() string {
 	if i < 0 || i >= WinType(len(_WinType_index)-1) {